@@ -1,22 +1,32 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"github.com/egor3f/rssalchemy/internal/api/http/pb"
 	"github.com/egor3f/rssalchemy/internal/config"
 	dummycookies "github.com/egor3f/rssalchemy/internal/cookiemgr/dummy"
 	"github.com/egor3f/rssalchemy/internal/dateparser"
 	"github.com/egor3f/rssalchemy/internal/extractors/pwextractor"
+	"github.com/egor3f/rssalchemy/internal/feed"
 	"github.com/egor3f/rssalchemy/internal/limiter/dummy"
 	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/presets"
+	"github.com/egor3f/rssalchemy/internal/specs"
 	"github.com/felixge/fgprof"
 	"github.com/labstack/gommon/log"
-	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 )
 
+const formatResult = "result"
+
 func main() {
 	log.SetLevel(log.DEBUG)
 	log.SetHeader(`${time_rfc3339_nano} ${level}`)
@@ -24,8 +34,27 @@ func main() {
 	outFile := flag.String("o", "", "Output file name")
 	skipOutput := flag.Bool("s", false, "Skip json output; show just logs")
 	useProfiler := flag.Bool("p", false, "Use profiler")
+	presetName := flag.String("preset", "", "Take task from preset instead of task file")
+	recordDir := flag.String("record", "", "Record page and golden result of preset as test fixture into directory")
+	format := flag.String("format", formatResult, "Output format: result (raw task result), atom, rss or json")
+	htmlFile := flag.String("html", "", "Parse saved html file (- for stdin) instead of fetching page, print per-field matches")
+	baseURL := flag.String("base-url", "", "Base url of saved html for resolving links, task url by default")
+	watch := flag.Bool("watch", false, "With -html: re-run whenever html file or specs file changes")
+	now := flag.String("now", "", "Current time (RFC3339) for parsing relative dates, e.g. of old saved html; real time by default")
 	flag.Parse()
 
+	if *watch && len(*htmlFile) == 0 {
+		log.Panicf("watch mode requires html file")
+	}
+
+	if *format != formatResult && !slices.Contains(feed.Formats, *format) {
+		log.Panicf("unknown output format: %s", *format)
+	}
+
+	if len(*recordDir) > 0 && len(*presetName) == 0 {
+		log.Panicf("record mode requires preset")
+	}
+
 	if *useProfiler {
 		//goland:noinspection GoUnhandledErrorResult
 		//defer fgtrace.Config{Dst: fgtrace.File(fmt.Sprintf("fgtrace_%d.json", time.Now().Unix()))}.Trace().Stop()
@@ -37,9 +66,9 @@ func main() {
 		defer stop()
 	}
 
-	taskFileName := "task.json"
+	input := "task.json"
 	if flag.NArg() > 0 {
-		taskFileName = flag.Arg(0)
+		input = flag.Arg(0)
 	}
 
 	out := os.Stdout
@@ -53,14 +82,66 @@ func main() {
 		defer out.Close()
 	}
 
-	task, err := loadTask(taskFileName)
+	cfg, err := config.Read()
 	if err != nil {
-		log.Panicf("load task: %v", err)
+		log.Panicf("read config: %v", err)
 	}
 
-	cfg, err := config.Read()
+	registry, err := presets.New(cfg.PresetsDir)
 	if err != nil {
-		log.Panicf("read config: %v", err)
+		log.Panicf("load presets: %v", err)
+	}
+
+	loadInputTask := func() (models.Task, error) {
+		if len(*presetName) > 0 {
+			return loadTaskFromSpecs(registry, presets.ParamPrefix+*presetName)
+		}
+		return loadTask(registry, input)
+	}
+	currentTime := time.Now
+	if len(*now) > 0 {
+		fixed, err := time.Parse(time.RFC3339, *now)
+		if err != nil {
+			log.Panicf("parse now: %v", err)
+		}
+		currentTime = func() time.Time {
+			return fixed
+		}
+	}
+	// fixtures are parsed relative to fixed time, so relative dates are reproducible
+	if len(*recordDir) > 0 {
+		currentTime = func() time.Time {
+			return pwextractor.FixtureTime
+		}
+	}
+	dp := &dateparser.DateParser{
+		CurrentTimeFunc: currentTime,
+	}
+
+	if len(*htmlFile) > 0 {
+		offline := offlineRun{
+			loadTask:   loadInputTask,
+			pagePath:   *htmlFile,
+			baseURL:    *baseURL,
+			dateParser: dp,
+			out:        out,
+		}
+		if *watch {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			err = offline.watch(ctx, []string{*htmlFile, input})
+		} else {
+			err = offline.run(context.Background())
+		}
+		if err != nil {
+			log.Panicf("offline: %v", err)
+		}
+		return
+	}
+
+	task, err := loadInputTask()
+	if err != nil {
+		log.Panicf("load task: %v", err)
 	}
 
 	pwe, err := pwextractor.New(pwextractor.Config{
@@ -68,11 +149,7 @@ func main() {
 		FlareSolverrURL:        cfg.FlareSolverrURL,
 		FlareSolverrMaxTimeout: cfg.FlareSolverrMaxTimeout,
 		FlareSolverrWait:       cfg.FlareSolverrWait,
-		DateParser: &dateparser.DateParser{
-			CurrentTimeFunc: func() time.Time {
-				return time.Date(2025, 01, 10, 10, 00, 00, 00, time.UTC)
-			},
-		},
+		DateParser:             dp,
 		CookieManager: dummycookies.New(),
 		Limiter:       &dummy.Limiter{},
 	})
@@ -85,12 +162,24 @@ func main() {
 		}
 	}()
 
+	if len(*recordDir) > 0 {
+		if err := recordFixture(pwe, task, *presetName, *recordDir); err != nil {
+			log.Panicf("record fixture: %v", err)
+		}
+		return
+	}
+
 	start := time.Now()
-	result, err := pwe.Extract(task)
+	var result *models.TaskResult
+	if task.TaskType == models.TaskTypeExtractJSON {
+		result, err = pwe.ExtractJSON(context.Background(), task)
+	} else {
+		result, err = pwe.Extract(context.Background(), task)
+	}
 	log.Infof("Extract took %v ms", time.Since(start).Milliseconds())
 	if err != nil {
 		log.Errorf("extract: %v", err)
-		scrResult, err := pwe.Screenshot(task)
+		scrResult, err := pwe.Screenshot(context.Background(), task)
 		if err != nil {
 			log.Errorf("screenshot failed: %v", err)
 			panic(err)
@@ -103,9 +192,16 @@ func main() {
 	}
 
 	if !*skipOutput {
-		resultStr, err := json.MarshalIndent(result, "", "\t")
+		var resultStr []byte
+		if *format == formatResult {
+			resultStr, err = json.MarshalIndent(result, "", "\t")
+		} else {
+			var feedStr string
+			feedStr, err = feed.Render(*format, task, *result)
+			resultStr = []byte(feedStr)
+		}
 		if err != nil {
-			log.Panicf("marshal result: %v", err)
+			log.Panicf("render output: %v", err)
 		}
 		n, err := out.Write(resultStr)
 		if err != nil {
@@ -115,22 +211,86 @@ func main() {
 	}
 }
 
-func loadTask(taskFileName string) (models.Task, error) {
-	taskFile, err := os.Open(taskFileName)
-	if err != nil {
-		return models.Task{}, fmt.Errorf("open task file: %w", err)
+// loadTask takes task from input, which is one of:
+// render url (…/render/<specs>), encoded specs or preset (rssalchemy:…, preset:<name>),
+// specs json (inline or file) or task json file
+func loadTask(registry *presets.Registry, input string) (models.Task, error) {
+	if strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://") {
+		param, err := specs.RenderParam(input)
+		if err != nil {
+			return models.Task{}, err
+		}
+		return loadTaskFromSpecs(registry, param)
+	}
+	if strings.HasPrefix(input, specs.PresetPrefix) || strings.HasPrefix(input, presets.ParamPrefix) {
+		return loadTaskFromSpecs(registry, input)
+	}
+
+	var contents []byte
+	if strings.HasPrefix(strings.TrimSpace(input), "{") {
+		contents = []byte(input)
+	} else {
+		var err error
+		contents, err = os.ReadFile(input)
+		if err != nil {
+			return models.Task{}, fmt.Errorf("read file: %w", err)
+		}
 	}
-	defer taskFile.Close()
 
-	fileContents, err := io.ReadAll(taskFile)
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(contents, &fields); err != nil {
+		return models.Task{}, fmt.Errorf("unmarshal input: %w", err)
+	}
+	if _, isTask := fields["TaskType"]; isTask {
+		var task models.Task
+		if err := json.Unmarshal(contents, &task); err != nil {
+			return models.Task{}, fmt.Errorf("unmarshal task: %w", err)
+		}
+		return task, nil
+	}
+
+	// same format as preview endpoint accepts
+	inputSpecs := &pb.Specs{}
+	if err := json.Unmarshal(contents, inputSpecs); err != nil {
+		return models.Task{}, fmt.Errorf("unmarshal specs: %w", err)
+	}
+	if err := specs.Validate(inputSpecs); err != nil {
+		return models.Task{}, err
+	}
+	return specs.ToTask(inputSpecs)
+}
+
+// loadTaskFromSpecs decodes render url param exactly like render endpoint
+func loadTaskFromSpecs(registry *presets.Registry, param string) (models.Task, error) {
+	decoded, err := registry.DecodeParam(param)
 	if err != nil {
-		return models.Task{}, fmt.Errorf("read file: %w", err)
+		return models.Task{}, fmt.Errorf("decode specs: %w", err)
 	}
+	return specs.ToTask(decoded)
+}
 
-	var task models.Task
-	if err := json.Unmarshal(fileContents, &task); err != nil {
-		return models.Task{}, fmt.Errorf("unmarshal task: %w", err)
+// recordFixture saves page of preset and its golden result, which are replayed by pwextractor tests
+func recordFixture(pwe *pwextractor.PwExtractor, task models.Task, name string, dir string) error {
+	page, err := pwe.FetchFixturePage(context.Background(), task)
+	if err != nil {
+		return fmt.Errorf("fetch page: %w", err)
+	}
+	result, err := pwextractor.ParseFixture(context.Background(), task, page)
+	if err != nil {
+		return fmt.Errorf("parse page: %w", err)
+	}
+	golden, err := json.MarshalIndent(result, "", "\t")
+	if err != nil {
+		return fmt.Errorf("marshal result: %w", err)
 	}
 
-	return task, err
+	pageFile, goldenFile := pwextractor.FixtureFiles(name, task)
+	if err := os.WriteFile(filepath.Join(dir, pageFile), page, 0644); err != nil {
+		return fmt.Errorf("write page: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, goldenFile), append(golden, '\n'), 0644); err != nil {
+		return fmt.Errorf("write golden result: %w", err)
+	}
+	log.Infof("Recorded fixture of %s: %d items", name, len(result.Items))
+	return nil
 }
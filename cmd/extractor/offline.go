@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/extractors/pwextractor"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/transform"
+	"github.com/labstack/gommon/log"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const watchInterval = 500 * time.Millisecond
+
+// previewFields is order of fields in offline report
+var previewFields = []string{
+	transform.FieldTitle,
+	transform.FieldLink,
+	transform.FieldDescription,
+	transform.FieldAuthor,
+	transform.FieldCreated,
+	transform.FieldContent,
+	transform.FieldEnclosure,
+}
+
+// offlineRun parses saved html page with html parser instead of fetching it through flaresolverr
+type offlineRun struct {
+	loadTask   func() (models.Task, error)
+	pagePath   string // "-" means stdin
+	baseURL    string // task url if empty
+	dateParser pwextractor.DateParser
+	out        io.Writer
+}
+
+func (r offlineRun) run(ctx context.Context) error {
+	task, err := r.loadTask()
+	if err != nil {
+		return fmt.Errorf("load task: %w", err)
+	}
+	if task.TaskType != models.TaskTypeExtract {
+		return fmt.Errorf("offline mode supports only html sources, got %s", task.TaskType)
+	}
+
+	var page []byte
+	if r.pagePath == "-" {
+		page, err = io.ReadAll(os.Stdin)
+	} else {
+		page, err = os.ReadFile(r.pagePath)
+	}
+	if err != nil {
+		return fmt.Errorf("read page: %w", err)
+	}
+
+	baseURL := r.baseURL
+	if baseURL == "" {
+		baseURL = task.URL
+	}
+	result := pwextractor.PreviewPage(ctx, task, string(page), baseURL, r.dateParser)
+	printPreview(r.out, result)
+	return nil
+}
+
+// watch runs parser every time one of files changes, until context is cancelled.
+// Errors are printed and don't stop watching, because files are usually being edited.
+func (r offlineRun) watch(ctx context.Context, files []string) error {
+	if r.pagePath == "-" {
+		return fmt.Errorf("can't watch stdin")
+	}
+	runOnce := func() {
+		if err := r.run(ctx); err != nil {
+			log.Errorf("offline run: %v", err)
+		}
+	}
+
+	states := fileStates(files)
+	runOnce()
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		current := fileStates(files)
+		if current == states {
+			continue
+		}
+		states = current
+		_, _ = fmt.Fprintf(r.out, "\n==== %s: changed, re-running ====\n", time.Now().Format(time.TimeOnly))
+		runOnce()
+	}
+}
+
+// fileStates returns modification times and sizes of files, missing files are skipped
+func fileStates(files []string) string {
+	var b strings.Builder
+	for _, name := range files {
+		info, err := os.Stat(name)
+		if err != nil {
+			continue
+		}
+		_, _ = fmt.Fprintf(&b, "%s:%d:%d;", name, info.ModTime().UnixNano(), info.Size())
+	}
+	return b.String()
+}
+
+func printPreview(w io.Writer, result *models.PreviewTaskResult) {
+	_, _ = fmt.Fprintf(w, "Page title: %q, posts matched: %d\n", result.Title, result.PostsMatched)
+	for i, post := range result.Posts {
+		status := "ok"
+		if post.Skipped {
+			status = "skipped: " + post.SkipReason
+		}
+		_, _ = fmt.Fprintf(w, "\nPost %d: %s\n", i+1, status)
+		for _, field := range previewFields {
+			matches, matched := post.SelectorMatches[field]
+			raw, hasRaw := post.Raw[field]
+			if !matched && !hasRaw {
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "  %-12s matches=%d raw=%q", field, matches, truncate(raw))
+			if value := post.Fields[field]; value != raw {
+				_, _ = fmt.Fprintf(w, " value=%q", truncate(value))
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+		if post.Created != nil {
+			_, _ = fmt.Fprintf(w, "  %-12s %s\n", "date", post.Created.Format(time.RFC3339))
+		}
+		if post.DateError != "" {
+			_, _ = fmt.Fprintf(w, "  %-12s %s\n", "date error", post.DateError)
+		}
+	}
+	if result.Error != "" {
+		_, _ = fmt.Fprintf(w, "\nError: %s\n", result.Error)
+	}
+}
+
+// truncate shortens long values like content, so report stays readable
+func truncate(s string) string {
+	const maxLen = 120
+	if r := []rune(s); len(r) > maxLen {
+		return string(r[:maxLen]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"github.com/egor3f/rssalchemy/internal/extractors/pwextractor"
+	"github.com/egor3f/rssalchemy/internal/extractors/pwextractor/flaretest"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/presets"
+	"github.com/egor3f/rssalchemy/internal/specs"
+	"github.com/labstack/gommon/log"
+	"net/http"
+	"path/filepath"
+)
+
+// Fake FlareSolverr for offline development: serves recorded pages of presets instead of real sites.
+// Worker uses it with FLARESOLVERR_URL=http://localhost:8191
+func main() {
+	log.SetHeader(`${time_rfc3339_nano} ${level}`)
+
+	addr := flag.String("addr", "127.0.0.1:8191", "Listen address")
+	fixturesDir := flag.String("fixtures", "internal/extractors/pwextractor/testdata/presets", "Directory with preset fixtures")
+	presetsDir := flag.String("presets", "", "Directory with additional presets")
+	flag.Parse()
+
+	registry, err := presets.New(*presetsDir)
+	if err != nil {
+		log.Panicf("load presets: %v", err)
+	}
+
+	fake := flaretest.New()
+	for _, preset := range registry.Search("", "") {
+		presetSpecs, err := preset.Specs()
+		if err != nil {
+			log.Panicf("decode preset %s: %v", preset.Name, err)
+		}
+		task, err := specs.ToTask(presetSpecs)
+		if err != nil {
+			log.Panicf("preset %s task: %v", preset.Name, err)
+		}
+		if task.TaskType != models.TaskTypeExtract {
+			continue // json is fetched without flaresolverr
+		}
+		pageFile, _ := pwextractor.FixtureFiles(preset.Name, task)
+		if err := fake.SetPageFile(task.URL, filepath.Join(*fixturesDir, pageFile)); err != nil {
+			log.Warnf("preset %s has no fixture: %v", preset.Name, err)
+			continue
+		}
+		log.Infof("Serving %s for %s", pageFile, task.URL)
+	}
+
+	log.Infof("Fake flaresolverr listening on %s", *addr)
+	if err := http.ListenAndServe(*addr, fake); err != nil {
+		log.Panicf("listen: %v", err)
+	}
+}
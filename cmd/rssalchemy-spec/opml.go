@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/presets"
+	"github.com/egor3f/rssalchemy/internal/specs"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// importTimeout is long because server renders every feed which isn't cached yet
+const importTimeout = 10 * time.Minute
+
+var linkIDRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// apiClient calls opml endpoints of instance, api key is taken from -key or RSSALCHEMY_API_KEY
+type apiClient struct {
+	server string
+	key    string
+	client *http.Client
+}
+
+func apiFlags(fs *flag.FlagSet) func() (apiClient, error) {
+	server := fs.String("server", "", "Url of rssalchemy instance, like https://rss.example.com")
+	key := fs.String("key", os.Getenv("RSSALCHEMY_API_KEY"), "Api key, RSSALCHEMY_API_KEY by default")
+	return func() (apiClient, error) {
+		if *server == "" {
+			return apiClient{}, fmt.Errorf("-server is required")
+		}
+		return apiClient{
+			server: strings.TrimSuffix(*server, "/"),
+			key:    *key,
+			client: &http.Client{Timeout: importTimeout},
+		}, nil
+	}
+}
+
+func (a apiClient) post(path string, contentType string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodPost, a.server+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if a.key != "" {
+		req.Header.Set("X-Api-Key", a.key)
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request %s: %s: %s", path, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return respBody, nil
+}
+
+// cmdOPMLExport makes OPML of specs and short links. Arguments are specs in any form,
+// short link urls or link ids; titles are taken by server from cached results.
+func cmdOPMLExport(args []string) error {
+	fs := flag.NewFlagSet("opml-export", flag.ExitOnError)
+	client := apiFlags(fs)
+	title := fs.String("title", "", "Title of OPML")
+	outFile := fs.String("o", "", "Output file, stdout by default")
+	_ = fs.Parse(args)
+	api, err := client()
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("expected specs or short links")
+	}
+
+	req := map[string]any{"title": *title, "base_url": api.server}
+	var specsParams, links []string
+	for _, arg := range fs.Args() {
+		if _, id, isLink := strings.Cut(arg, specs.FeedPath); isLink {
+			links = append(links, strings.SplitN(id, "?", 2)[0])
+			continue
+		}
+		if linkIDRegex.MatchString(arg) {
+			links = append(links, arg)
+			continue
+		}
+		if strings.HasPrefix(arg, presets.ParamPrefix) {
+			specsParams = append(specsParams, arg)
+			continue
+		}
+		in, err := parseInput(arg)
+		if err != nil {
+			return fmt.Errorf("%s: %w", arg, err)
+		}
+		// check locally to report bad argument instead of whole request failure
+		if _, err := specs.Decode(in.encoded); err != nil {
+			return fmt.Errorf("%s: %w", arg, err)
+		}
+		specsParams = append(specsParams, in.encoded)
+	}
+	req["specs"], req["links"] = specsParams, links
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	doc, err := api.post("/api/v1/opml/export", "application/json", body)
+	if err != nil {
+		return err
+	}
+	if *outFile == "" {
+		_, err = os.Stdout.Write(doc)
+		return err
+	}
+	return os.WriteFile(*outFile, doc, 0644)
+}
+
+type importFeed struct {
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Items  int    `json:"items"`
+	Cached bool   `json:"cached"`
+}
+
+type importResponse struct {
+	Feeds   []importFeed `json:"feeds"`
+	OK      int          `json:"ok"`
+	Broken  int          `json:"broken"`
+	Skipped int          `json:"skipped"`
+}
+
+// cmdOPMLImport validates feeds of OPML on server and warms its cache, exits with error if some are broken
+func cmdOPMLImport(args []string) error {
+	fs := flag.NewFlagSet("opml-import", flag.ExitOnError)
+	client := apiFlags(fs)
+	noWarm := fs.Bool("no-warm", false, "Only validate specs, don't render feeds which are not cached")
+	_ = fs.Parse(args)
+	api, err := client()
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expected one opml file")
+	}
+
+	var doc []byte
+	if fs.Arg(0) == "-" {
+		doc, err = io.ReadAll(os.Stdin)
+	} else {
+		doc, err = os.ReadFile(fs.Arg(0))
+	}
+	if err != nil {
+		return fmt.Errorf("read opml: %w", err)
+	}
+	path := "/api/v1/opml/import"
+	if *noWarm {
+		path += "?" + url.Values{"warm": {"false"}}.Encode()
+	}
+	respBody, err := api.post(path, "text/x-opml", doc)
+	if err != nil {
+		return err
+	}
+	var resp importResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "STATUS\tITEMS\tTITLE\tURL\tERROR")
+	for _, f := range resp.Feeds {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", f.Status, f.Items, f.Title, f.URL, f.Error)
+	}
+	_ = w.Flush()
+	fmt.Printf("\nok: %d, broken: %d, skipped: %d\n", resp.OK, resp.Broken, resp.Skipped)
+	if resp.Broken > 0 {
+		return fmt.Errorf("%d broken feeds", resp.Broken)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+// Command rssalchemy-spec encodes, decodes, edits and validates specs of feed urls and presets, for scripts.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/api/http/pb"
+	"github.com/egor3f/rssalchemy/internal/specs"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const usage = `Usage: rssalchemy-spec <command> [flags] [args]
+
+Specs input is a render url, a preset (rssalchemy:...), encoded specs (1:...) or - for stdin.
+
+Commands:
+  encode [-preset] [-base-url url] [file|-]   encode specs json (same format as preview api accepts)
+  decode <specs>                              print specs json
+  edit <specs> field=value...                 change fields, output in the same form as input
+  validate <specs>                            check specs like render endpoint does
+  upgrade <specs>                             re-encode old specs with current version
+  opml-export -server url [-key k] [-title t] [-o file] <specs|link>...
+                                              make OPML with render urls and cached titles
+  opml-import -server url [-key k] [-no-warm] <file|->
+                                              validate feeds of OPML and render not cached ones
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	commands := map[string]func(args []string) error{
+		"encode":   cmdEncode,
+		"decode":   cmdDecode,
+		"edit":     cmdEdit,
+		"validate": cmdValidate,
+		"upgrade":  cmdUpgrade,
+
+		"opml-export": cmdOPMLExport,
+		"opml-import": cmdOPMLImport,
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err := cmd(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+// input is specs argument, its form is kept on output
+type input struct {
+	// prefix is url up to encoded specs for render url, preset prefix for preset
+	prefix  string
+	encoded string
+	// signed url becomes invalid after change
+	signed bool
+}
+
+func parseInput(arg string) (input, error) {
+	if arg == "-" {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return input{}, fmt.Errorf("read stdin: %w", err)
+		}
+		arg = strings.TrimSpace(line)
+	}
+	if strings.HasPrefix(arg, "http://") || strings.HasPrefix(arg, "https://") {
+		param, err := specs.RenderParam(arg)
+		if err != nil {
+			return input{}, err
+		}
+		u, err := url.Parse(arg)
+		if err != nil {
+			return input{}, fmt.Errorf("parse url: %w", err)
+		}
+		signed := u.Query().Has("sig")
+		prefix, _, _ := strings.Cut(u.EscapedPath(), specs.RenderPath)
+		u.Path, u.RawPath, u.RawQuery, u.Fragment = "", "", "", ""
+		return input{prefix: u.String() + prefix + specs.RenderPath, encoded: param, signed: signed}, nil
+	}
+	if encoded, isPreset := strings.CutPrefix(arg, specs.PresetPrefix); isPreset {
+		return input{prefix: specs.PresetPrefix, encoded: encoded}, nil
+	}
+	return input{encoded: arg}, nil
+}
+
+// format returns specs in the same form as input
+func (in input) format(s *pb.Specs) (string, error) {
+	encoded, err := specs.Encode(s)
+	if err != nil {
+		return "", err
+	}
+	if in.signed {
+		fmt.Fprintln(os.Stderr, "warning: signature is dropped, sign new url with /api/v1/sign")
+	}
+	return in.prefix + encoded, nil
+}
+
+func singleArg(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("expected one specs argument, got %d", len(args))
+	}
+	return args[0], nil
+}
+
+func cmdEncode(args []string) error {
+	fs := flag.NewFlagSet("encode", flag.ExitOnError)
+	preset := fs.Bool("preset", false, "Output preset (rssalchemy:...) instead of encoded specs")
+	baseURL := fs.String("base-url", "", "Output render url of instance, like https://rss.example.com")
+	_ = fs.Parse(args)
+	if *preset && *baseURL != "" {
+		return fmt.Errorf("-preset and -base-url are mutually exclusive")
+	}
+
+	var data []byte
+	var err error
+	if fs.NArg() == 0 || fs.Arg(0) == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(fs.Arg(0))
+	}
+	if err != nil {
+		return fmt.Errorf("read specs: %w", err)
+	}
+	s := &pb.Specs{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return fmt.Errorf("unmarshal specs: %w", err)
+	}
+	if err := specs.Validate(s); err != nil {
+		return err
+	}
+
+	in := input{}
+	if *preset {
+		in.prefix = specs.PresetPrefix
+	} else if *baseURL != "" {
+		in.prefix = strings.TrimSuffix(*baseURL, "/") + specs.RenderPath
+	}
+	out, err := in.format(s)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	return nil
+}
+
+func decodeArg(args []string) (input, *pb.Specs, error) {
+	arg, err := singleArg(args)
+	if err != nil {
+		return input{}, nil, err
+	}
+	in, err := parseInput(arg)
+	if err != nil {
+		return input{}, nil, err
+	}
+	s, err := specs.Decode(in.encoded)
+	if err != nil {
+		return input{}, nil, err
+	}
+	return in, s, nil
+}
+
+func cmdDecode(args []string) error {
+	_, s, err := decodeArg(args)
+	if err != nil {
+		return err
+	}
+	return printJSON(s)
+}
+
+func cmdValidate(args []string) error {
+	in, s, err := decodeArg(args)
+	if err != nil {
+		return err
+	}
+	if err := specs.Validate(s); err != nil {
+		return err
+	}
+	version, err := specs.EncodedVersion(in.encoded)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("ok, version %d\n", version)
+	return nil
+}
+
+func cmdUpgrade(args []string) error {
+	in, s, err := decodeArg(args)
+	if err != nil {
+		return err
+	}
+	out, err := in.format(s)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	return nil
+}
+
+// cmdEdit sets fields by json names. Values are json literals (numbers for enums) or plain strings.
+func cmdEdit(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("expected specs and at least one field=value")
+	}
+	in, s, err := decodeArg(args[:1])
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshal specs: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("unmarshal specs: %w", err)
+	}
+	for _, arg := range args[1:] {
+		name, value, found := strings.Cut(arg, "=")
+		if !found {
+			return fmt.Errorf("invalid field assignment: %s", arg)
+		}
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("unknown field: %s", name)
+		}
+		if json.Valid([]byte(value)) {
+			fields[name] = json.RawMessage(value)
+		} else {
+			quoted, _ := json.Marshal(value)
+			fields[name] = quoted
+		}
+	}
+
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("marshal fields: %w", err)
+	}
+	edited := &pb.Specs{}
+	if err := json.Unmarshal(data, edited); err != nil {
+		return fmt.Errorf("invalid field value: %w", err)
+	}
+	if err := specs.Validate(edited); err != nil {
+		return err
+	}
+	out, err := in.format(edited)
+	if err != nil {
+		return err
+	}
+	fmt.Println(out)
+	return nil
+}
+
+func printJSON(s *pb.Specs) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal specs: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
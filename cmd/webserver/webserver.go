@@ -2,15 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	wizard_vue "github.com/egor3f/rssalchemy/frontend/wizard-vue"
 	"github.com/egor3f/rssalchemy/internal/adapters/natsadapter"
 	httpApi "github.com/egor3f/rssalchemy/internal/api/http"
 	"github.com/egor3f/rssalchemy/internal/config"
+	"github.com/egor3f/rssalchemy/internal/health"
+	"github.com/egor3f/rssalchemy/internal/limiter/redisleaky"
+	"github.com/egor3f/rssalchemy/internal/presets"
+	"github.com/egor3f/rssalchemy/internal/signing"
+	"github.com/egor3f/rssalchemy/internal/tracing"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/labstack/gommon/log"
 	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 	"golang.org/x/time/rate"
 	"net"
 	"net/http"
@@ -34,6 +42,22 @@ func main() {
 	baseCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer stop()
 
+	shutdownTracing, err := tracing.Setup(baseCtx, tracing.Config{
+		Endpoint:    cfg.TracingEndpoint,
+		ServiceName: "rssalchemy-webserver",
+		SampleRatio: cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		log.Panicf("setup tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Errorf("shutdown tracing: %v", err)
+		}
+	}()
+
 	natsc, err := nats.Connect(cfg.NatsUrl)
 	if err != nil {
 		log.Panicf("nats connect failed: %v", err)
@@ -49,8 +73,50 @@ func main() {
 		log.Panicf("create nats adapter: %v", err)
 	}
 
+	apiKeyStore, err := natsadapter.NewApiKeyStore(natsc)
+	if err != nil {
+		log.Panicf("create api key store: %v", err)
+	}
+
+	// redis is used only by admin api to inspect per-domain limiter of workers
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: cfg.RedisUrl,
+	})
+	defer func() {
+		if err := redisClient.Close(); err != nil {
+			log.Errorf("close redis client: %v", err)
+		}
+	}()
+	perDomainLimiter := redisleaky.New(
+		rate.Every(time.Duration(float64(time.Second)*cfg.PerDomainRateLimitEvery)),
+		int64(cfg.PerDomainRateLimitCapacity),
+		redisClient,
+		"per_domain_limiter",
+	)
+
+	linkStore, err := natsadapter.NewLinkStore(natsc)
+	if err != nil {
+		log.Panicf("create link store: %v", err)
+	}
+
+	presetRegistry, err := presets.New(cfg.PresetsDir)
+	if err != nil {
+		log.Panicf("load presets: %v", err)
+	}
+
+	var signer *signing.Signer
+	if len(cfg.SpecsSigningKeys) > 0 {
+		if signer, err = signing.New(cfg.SpecsSigningKeys); err != nil {
+			log.Panicf("create signer: %v", err)
+		}
+	}
+
+	go na.PollQueueDepth(baseCtx)
+
 	e := echo.New()
-	e.Use(middleware.Logger())
+	e.Use(httpApi.AccessLogger())
+	e.Use(httpApi.MetricsMiddleware)
+	e.Use(httpApi.TracingMiddleware)
 	if !cfg.Debug {
 		e.Use(middleware.Recover())
 	}
@@ -63,20 +129,56 @@ func main() {
 		Filesystem: http.FS(wizard_vue.EmbedFS),
 	}))
 
-	apiHandler := httpApi.New(
-		na,
-		na,
-		rate.Every(time.Duration(float64(time.Second)*cfg.TaskRateLimitEvery)),
-		cfg.TaskRateLimitBurst,
-		cfg.Debug,
-	)
+	apiHandler := httpApi.New(httpApi.Config{
+		WorkQueue:      na,
+		Cache:          na,
+		RateLimit:      rate.Every(time.Duration(float64(time.Second) * cfg.TaskRateLimitEvery)),
+		RateLimitBurst: cfg.TaskRateLimitBurst,
+		Auth: httpApi.AuthConfig{
+			Required:          cfg.ApiKeyRequired,
+			StaticKeys:        cfg.ApiKeys,
+			AdminKey:          cfg.AdminApiKey,
+			Store:             apiKeyStore,
+			DefaultRateLimit:  rate.Every(time.Duration(float64(time.Second) * cfg.ApiKeyRateLimitEvery)),
+			DefaultBurst:      cfg.ApiKeyRateLimitBurst,
+			DefaultDailyQuota: cfg.ApiKeyDailyQuota,
+		},
+		Admin: httpApi.AdminConfig{
+			Cache:   na,
+			Queue:   na,
+			Limiter: perDomainLimiter,
+		},
+		Signer:  signer,
+		Links:   linkStore,
+		Presets: presetRegistry,
+		Debug:   cfg.Debug,
+	})
 	apiHandler.SetupRoutes(e.Group("/api/v1"))
+	checker := health.New().Add("nats", na.Ping)
+	e.GET("/healthz", echo.WrapHandler(checker.LiveHandler()))
+	e.GET("/readyz", echo.WrapHandler(checker.ReadyHandler()))
 
 	go func() {
 		if err := e.Start(cfg.WebserverAddress); err != nil && err != http.ErrServerClosed {
 			e.Logger.Errorf("http server error, shutting down: %v", err)
 		}
 	}()
+	// metrics are on separate address, so they are not exposed with public api
+	if cfg.WebserverMetricsAddress != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		metricsServer := &http.Server{Addr: cfg.WebserverMetricsAddress, Handler: mux}
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Errorf("metrics server: %v", err)
+			}
+		}()
+		defer func() {
+			if err := metricsServer.Close(); err != nil {
+				log.Errorf("close metrics server: %v", err)
+			}
+		}()
+	}
 	<-baseCtx.Done()
 	log.Infof("stopping webserver gracefully")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
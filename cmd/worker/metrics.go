@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/egor3f/rssalchemy/internal/extractors/pwextractor"
+	"github.com/egor3f/rssalchemy/internal/health"
+	"github.com/egor3f/rssalchemy/internal/limiter"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net"
+	"net/http"
+)
+
+var (
+	tasksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rssalchemy_tasks_total",
+		Help: "Processed tasks by type and result (ok or error class).",
+	}, []string{"type", "result"})
+	taskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rssalchemy_task_duration_seconds",
+		Help:    "Task processing time by type and result.",
+		Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"type", "result"})
+	taskItems = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rssalchemy_task_items",
+		Help:    "Items extracted per feed task.",
+		Buckets: []float64{0, 1, 5, 10, 20, 50, 100, 200},
+	}, []string{"type"})
+)
+
+// errorClass groups task errors for metrics
+func errorClass(err error) string {
+	var rejected *pwextractor.HostRejectedError
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.As(err, &rejected):
+		return "rejected"
+	case errors.Is(err, limiter.ErrLimitReached):
+		return "limited"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.Is(err, pwextractor.ErrFlareSolverr):
+		return "flaresolverr"
+	}
+	return "error"
+}
+
+// httpMux serves metrics and health endpoints of worker
+func httpMux(checker *health.Checker) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/healthz", checker.LiveHandler())
+	mux.Handle("/readyz", checker.ReadyHandler())
+	return mux
+}
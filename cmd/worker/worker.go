@@ -3,18 +3,23 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/egor3f/rssalchemy/internal/adapters/natsadapter"
 	"github.com/egor3f/rssalchemy/internal/config"
 	natscookies "github.com/egor3f/rssalchemy/internal/cookiemgr/nats"
 	"github.com/egor3f/rssalchemy/internal/dateparser"
 	"github.com/egor3f/rssalchemy/internal/extractors/pwextractor"
+	"github.com/egor3f/rssalchemy/internal/health"
 	"github.com/egor3f/rssalchemy/internal/limiter/redisleaky"
 	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/tracing"
 	"github.com/labstack/gommon/log"
 	"github.com/nats-io/nats.go"
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/time/rate"
+	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"time"
@@ -38,6 +43,22 @@ func main() {
 	baseCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer stop()
 
+	shutdownTracing, err := tracing.Setup(baseCtx, tracing.Config{
+		Endpoint:    cfg.TracingEndpoint,
+		ServiceName: "rssalchemy-worker",
+		SampleRatio: cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		log.Panicf("setup tracing: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Errorf("shutdown tracing: %v", err)
+		}
+	}()
+
 	natsc, err := nats.Connect(cfg.NatsUrl)
 	if err != nil {
 		log.Panicf("nats connect failed: %v", err)
@@ -82,6 +103,8 @@ func main() {
 		FlareSolverrURL:        cfg.FlareSolverrURL,
 		FlareSolverrMaxTimeout: cfg.FlareSolverrMaxTimeout,
 		FlareSolverrWait:       cfg.FlareSolverrWait,
+		AllowedHosts:           cfg.AllowedHosts,
+		DeniedHosts:            cfg.DeniedHosts,
 		DateParser: &dateparser.DateParser{
 			CurrentTimeFunc: time.Now,
 		},
@@ -97,27 +120,60 @@ func main() {
 		}
 	}()
 
-	err = qc.ConsumeQueue(baseCtx, func(taskPayload []byte) (cacheKey string, resultPayoad []byte, errRet error) {
+	go qc.PollQueueDepth(baseCtx)
+	checker := health.New().
+		Add("nats", qc.Ping).
+		Add("redis", func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		}).
+		Add("flaresolverr", pwe.CheckFlareSolverr)
+	if cfg.WorkerMetricsAddress != "" {
+		metricsServer := &http.Server{Addr: cfg.WorkerMetricsAddress, Handler: httpMux(checker)}
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Errorf("metrics server: %v", err)
+			}
+		}()
+		defer func() {
+			if err := metricsServer.Close(); err != nil {
+				log.Errorf("close metrics server: %v", err)
+			}
+		}()
+	}
+
+	err = qc.ConsumeQueue(baseCtx, func(ctx context.Context, taskPayload []byte) (cacheKey string, resultPayoad []byte, errRet error) {
 		var task models.Task
 		if err := json.Unmarshal(taskPayload, &task); err != nil {
 			errRet = fmt.Errorf("unmarshal task: %w", err)
 			return
 		}
+		start := time.Now()
 		var result any
 		switch task.TaskType {
 		case models.TaskTypeExtract:
-			result, err = pwe.Extract(task)
+			result, err = pwe.Extract(ctx, task)
+		case models.TaskTypeExtractJSON:
+			result, err = pwe.ExtractJSON(ctx, task)
 		case models.TaskTypePageScreenshot:
-			result, err = pwe.Screenshot(task)
+			result, err = pwe.Screenshot(ctx, task)
+		case models.TaskTypePageSnapshot:
+			result, err = pwe.Snapshot(ctx, task)
+		case models.TaskTypePreview, models.TaskTypePreviewJSON:
+			result, err = pwe.Preview(ctx, task)
 		}
+		taskDuration.WithLabelValues(string(task.TaskType), errorClass(err)).Observe(time.Since(start).Seconds())
+		tasksTotal.WithLabelValues(string(task.TaskType), errorClass(err)).Inc()
+		if feed, ok := result.(*models.TaskResult); ok && err == nil {
+			taskItems.WithLabelValues(string(task.TaskType)).Observe(float64(len(feed.Items)))
+		}
+
+		resultPayoad, err = pwextractor.TaskResultPayload(result, err)
 		if err != nil {
 			errRet = fmt.Errorf("task processing: %w", err)
 			return
 		}
-		resultPayoad, err = json.Marshal(result)
-		if err != nil {
-			errRet = fmt.Errorf("marshal result: %w", err)
-			return
+		if err := qc.PutCacheEntry(cacheEntry(task)); err != nil {
+			log.Errorf("index cache entry: %v", err)
 		}
 		return task.CacheKey(), resultPayoad, errRet
 	})
@@ -125,3 +181,17 @@ func main() {
 		log.Panicf("consume queue: %v", err)
 	}
 }
+
+// cacheEntry describes task for admin api, without headers
+func cacheEntry(task models.Task) models.CacheEntry {
+	entry := models.CacheEntry{
+		Key:      task.CacheKey(),
+		SpecKey:  task.SpecKey(),
+		TaskType: task.TaskType,
+		URL:      task.URL,
+	}
+	if u, err := url.Parse(task.URL); err == nil {
+		entry.Domain = u.Hostname()
+	}
+	return entry
+}
@@ -1,5 +1,5 @@
 export type EnumValue = {
   label: string
-  value: number
+  value: number | boolean
 }
 export type Enum = EnumValue[]
@@ -5,6 +5,11 @@ import {rssalchemy, rssalchemy as pb} from '@/urlmaker/proto/specs.ts';
 const apiBase = import.meta.env.VITE_API_BASE || document.location.origin;
 const renderEndpoint = '/api/v1/render/';  // trailing slash
 const screenshotEndpoint = '/api/v1/screenshot';  // no trailing slash
+const snapshotEndpoint = '/api/v1/snapshot';  // no trailing slash
+const previewEndpoint = '/api/v1/preview';  // no trailing slash
+const signEndpoint = '/api/v1/sign';  // no trailing slash
+// api key is taken from wizard url (?key=...), it's required on instances with signed urls
+const apiKey = new URLSearchParams(document.location.search).get('key');
 export const presetPrefix = 'rssalchemy:';
 
 export async function decodeUrl(url: string): Promise<Specs> {
@@ -49,7 +54,8 @@ export async function decodeSpecsPart(encodedData: string): Promise<Specs> {
 }
 
 export async function encodeUrl(specs: Specs): Promise<string> {
-  return `${apiBase}${renderEndpoint}${await encodeSpecsPart(specs)}`
+  const specsPart = await encodeSpecsPart(specs);
+  return withSignature(`${apiBase}${renderEndpoint}${specsPart}`, {specs: specsPart});
 }
 
 export async function encodePreset(specs: Specs): Promise<string> {
@@ -66,6 +72,83 @@ export async function encodeSpecsPart(specs: Specs): Promise<string> {
   return `${version}:${encodedData}`;
 }
 
+// signParam returns signature of specs or url, or null if signing is disabled on server
+async function signParam(param: {specs: string} | {url: string}): Promise<string | null> {
+  const resp = await fetch(`${apiBase}${signEndpoint}`, {
+    method: 'POST',
+    headers: apiHeaders(),
+    body: JSON.stringify(param),
+  });
+  if (resp.status === 404) {
+    return null;
+  }
+  if (!resp.ok) {
+    const body = await resp.json().catch(() => ({message: resp.statusText}));
+    throw new Error(`sign url: ${body.message || resp.statusText}`);
+  }
+  return (await resp.json()).sig;
+}
+
+async function withSignature(url: string, param: {specs: string} | {url: string}): Promise<string> {
+  const sig = await signParam(param);
+  if (!sig) {
+    return url;
+  }
+  return `${url}${url.includes('?') ? '&' : '?'}sig=${encodeURIComponent(sig)}`;
+}
+
+function apiHeaders(): Record<string, string> {
+  const headers: Record<string, string> = {'Content-Type': 'application/json'};
+  if (apiKey) {
+    headers['X-Api-Key'] = apiKey;
+  }
+  return headers;
+}
+
+// withKey adds api key to urls opened by wizard itself, links for rss readers are signed instead
+function withKey(url: string): string {
+  return apiKey ? `${url}&key=${encodeURIComponent(apiKey)}` : url;
+}
+
 export function getScreenshotUrl(url: string): string {
-  return `${apiBase}${screenshotEndpoint}?url=${encodeURIComponent(url)}`;
+  return withKey(`${apiBase}${screenshotEndpoint}?url=${encodeURIComponent(url)}`);
+}
+
+// getOverlayScreenshotUrl returns screenshot url with nodes matched by selectors outlined
+export async function getOverlayScreenshotUrl(specs: Specs): Promise<string> {
+  return withKey(`${apiBase}${screenshotEndpoint}?specs=${encodeURIComponent(await encodeSpecsPart(specs))}`);
+}
+
+export async function getSnapshotUrl(specs: Specs): Promise<string> {
+  return withKey(`${apiBase}${snapshotEndpoint}?specs=${encodeURIComponent(await encodeSpecsPart(specs))}`);
+}
+
+export interface PostPreview {
+  selector_matches: Record<string, number>
+  raw: Record<string, string>
+  fields: Record<string, string>
+  created?: string
+  date_error?: string
+  skipped: boolean
+  skip_reason?: string
+}
+
+export interface PreviewResult {
+  error?: string
+  title: string
+  posts_matched: number
+  posts: PostPreview[] | null
+}
+
+export async function getPreview(specs: Specs): Promise<PreviewResult> {
+  const resp = await fetch(`${apiBase}${previewEndpoint}`, {
+    method: 'POST',
+    headers: apiHeaders(),
+    body: JSON.stringify(specs),
+  });
+  if (!resp.ok) {
+    const body = await resp.json().catch(() => ({message: resp.statusText}));
+    throw new Error(body.message || resp.statusText);
+  }
+  return await resp.json();
 }
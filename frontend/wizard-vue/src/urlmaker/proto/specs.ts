@@ -9,20 +9,57 @@ export namespace rssalchemy {
         InnerText = 0,
         Attribute = 1
     }
+    export enum SelectorType {
+        Css = 0,
+        XPath = 1
+    }
+    export enum SourceType {
+        Html = 0,
+        Json = 1
+    }
     export class Specs extends pb_1.Message {
         #one_of_decls: number[][] = [];
         constructor(data?: any[] | {
             url?: string;
+            source_type?: SourceType;
             selector_post?: string;
+            selector_post_type?: SelectorType;
             selector_title?: string;
+            selector_title_type?: SelectorType;
+            title_extract_from?: ExtractFrom;
+            title_attribute_name?: string;
+            transform_title?: string;
             selector_link?: string;
+            selector_link_type?: SelectorType;
+            link_attribute_name?: string;
+            transform_link?: string;
             selector_description?: string;
+            selector_description_type?: SelectorType;
+            description_extract_from?: ExtractFrom;
+            description_attribute_name?: string;
+            transform_description?: string;
             selector_author?: string;
+            selector_author_type?: SelectorType;
+            author_extract_from?: ExtractFrom;
+            author_attribute_name?: string;
+            author_link_attribute_name?: string;
+            transform_author?: string;
             selector_created?: string;
+            selector_created_type?: SelectorType;
             created_extract_from?: ExtractFrom;
             created_attribute_name?: string;
+            date_formats?: string;
+            date_languages?: string;
+            date_timezone?: string;
+            date_day_first?: boolean;
+            transform_created?: string;
             selector_content?: string;
+            selector_content_type?: SelectorType;
+            transform_content?: string;
             selector_enclosure?: string;
+            selector_enclosure_type?: SelectorType;
+            enclosure_attribute_name?: string;
+            transform_enclosure?: string;
             cache_lifetime?: string;
         }) {
             super();
@@ -31,36 +68,123 @@ export namespace rssalchemy {
                 if ("url" in data && data.url != undefined) {
                     this.url = data.url;
                 }
+                if ("source_type" in data && data.source_type != undefined) {
+                    this.source_type = data.source_type;
+                }
                 if ("selector_post" in data && data.selector_post != undefined) {
                     this.selector_post = data.selector_post;
                 }
+                if ("selector_post_type" in data && data.selector_post_type != undefined) {
+                    this.selector_post_type = data.selector_post_type;
+                }
                 if ("selector_title" in data && data.selector_title != undefined) {
                     this.selector_title = data.selector_title;
                 }
+                if ("selector_title_type" in data && data.selector_title_type != undefined) {
+                    this.selector_title_type = data.selector_title_type;
+                }
+                if ("title_extract_from" in data && data.title_extract_from != undefined) {
+                    this.title_extract_from = data.title_extract_from;
+                }
+                if ("title_attribute_name" in data && data.title_attribute_name != undefined) {
+                    this.title_attribute_name = data.title_attribute_name;
+                }
+                if ("transform_title" in data && data.transform_title != undefined) {
+                    this.transform_title = data.transform_title;
+                }
                 if ("selector_link" in data && data.selector_link != undefined) {
                     this.selector_link = data.selector_link;
                 }
+                if ("selector_link_type" in data && data.selector_link_type != undefined) {
+                    this.selector_link_type = data.selector_link_type;
+                }
+                if ("link_attribute_name" in data && data.link_attribute_name != undefined) {
+                    this.link_attribute_name = data.link_attribute_name;
+                }
+                if ("transform_link" in data && data.transform_link != undefined) {
+                    this.transform_link = data.transform_link;
+                }
                 if ("selector_description" in data && data.selector_description != undefined) {
                     this.selector_description = data.selector_description;
                 }
+                if ("selector_description_type" in data && data.selector_description_type != undefined) {
+                    this.selector_description_type = data.selector_description_type;
+                }
+                if ("description_extract_from" in data && data.description_extract_from != undefined) {
+                    this.description_extract_from = data.description_extract_from;
+                }
+                if ("description_attribute_name" in data && data.description_attribute_name != undefined) {
+                    this.description_attribute_name = data.description_attribute_name;
+                }
+                if ("transform_description" in data && data.transform_description != undefined) {
+                    this.transform_description = data.transform_description;
+                }
                 if ("selector_author" in data && data.selector_author != undefined) {
                     this.selector_author = data.selector_author;
                 }
+                if ("selector_author_type" in data && data.selector_author_type != undefined) {
+                    this.selector_author_type = data.selector_author_type;
+                }
+                if ("author_extract_from" in data && data.author_extract_from != undefined) {
+                    this.author_extract_from = data.author_extract_from;
+                }
+                if ("author_attribute_name" in data && data.author_attribute_name != undefined) {
+                    this.author_attribute_name = data.author_attribute_name;
+                }
+                if ("author_link_attribute_name" in data && data.author_link_attribute_name != undefined) {
+                    this.author_link_attribute_name = data.author_link_attribute_name;
+                }
+                if ("transform_author" in data && data.transform_author != undefined) {
+                    this.transform_author = data.transform_author;
+                }
                 if ("selector_created" in data && data.selector_created != undefined) {
                     this.selector_created = data.selector_created;
                 }
+                if ("selector_created_type" in data && data.selector_created_type != undefined) {
+                    this.selector_created_type = data.selector_created_type;
+                }
                 if ("created_extract_from" in data && data.created_extract_from != undefined) {
                     this.created_extract_from = data.created_extract_from;
                 }
                 if ("created_attribute_name" in data && data.created_attribute_name != undefined) {
                     this.created_attribute_name = data.created_attribute_name;
                 }
+                if ("date_formats" in data && data.date_formats != undefined) {
+                    this.date_formats = data.date_formats;
+                }
+                if ("date_languages" in data && data.date_languages != undefined) {
+                    this.date_languages = data.date_languages;
+                }
+                if ("date_timezone" in data && data.date_timezone != undefined) {
+                    this.date_timezone = data.date_timezone;
+                }
+                if ("date_day_first" in data && data.date_day_first != undefined) {
+                    this.date_day_first = data.date_day_first;
+                }
+                if ("transform_created" in data && data.transform_created != undefined) {
+                    this.transform_created = data.transform_created;
+                }
                 if ("selector_content" in data && data.selector_content != undefined) {
                     this.selector_content = data.selector_content;
                 }
+                if ("selector_content_type" in data && data.selector_content_type != undefined) {
+                    this.selector_content_type = data.selector_content_type;
+                }
+                if ("transform_content" in data && data.transform_content != undefined) {
+                    this.transform_content = data.transform_content;
+                }
                 if ("selector_enclosure" in data && data.selector_enclosure != undefined) {
                     this.selector_enclosure = data.selector_enclosure;
                 }
+                if ("selector_enclosure_type" in data && data.selector_enclosure_type != undefined) {
+                    this.selector_enclosure_type = data.selector_enclosure_type;
+                }
+                if ("enclosure_attribute_name" in data && data.enclosure_attribute_name != undefined) {
+                    this.enclosure_attribute_name = data.enclosure_attribute_name;
+                }
+                if ("transform_enclosure" in data && data.transform_enclosure != undefined) {
+                    this.transform_enclosure = data.transform_enclosure;
+                }
                 if ("cache_lifetime" in data && data.cache_lifetime != undefined) {
                     this.cache_lifetime = data.cache_lifetime;
                 }
@@ -72,42 +196,156 @@ export namespace rssalchemy {
         set url(value: string) {
             pb_1.Message.setField(this, 1, value);
         }
+        get source_type() {
+            return pb_1.Message.getFieldWithDefault(this, 13, SourceType.Html) as SourceType;
+        }
+        set source_type(value: SourceType) {
+            pb_1.Message.setField(this, 13, value);
+        }
         get selector_post() {
             return pb_1.Message.getFieldWithDefault(this, 2, "") as string;
         }
         set selector_post(value: string) {
             pb_1.Message.setField(this, 2, value);
         }
+        get selector_post_type() {
+            return pb_1.Message.getFieldWithDefault(this, 14, SelectorType.Css) as SelectorType;
+        }
+        set selector_post_type(value: SelectorType) {
+            pb_1.Message.setField(this, 14, value);
+        }
         get selector_title() {
             return pb_1.Message.getFieldWithDefault(this, 3, "") as string;
         }
         set selector_title(value: string) {
             pb_1.Message.setField(this, 3, value);
         }
+        get selector_title_type() {
+            return pb_1.Message.getFieldWithDefault(this, 15, SelectorType.Css) as SelectorType;
+        }
+        set selector_title_type(value: SelectorType) {
+            pb_1.Message.setField(this, 15, value);
+        }
+        get title_extract_from() {
+            return pb_1.Message.getFieldWithDefault(this, 29, ExtractFrom.InnerText) as ExtractFrom;
+        }
+        set title_extract_from(value: ExtractFrom) {
+            pb_1.Message.setField(this, 29, value);
+        }
+        get title_attribute_name() {
+            return pb_1.Message.getFieldWithDefault(this, 30, "") as string;
+        }
+        set title_attribute_name(value: string) {
+            pb_1.Message.setField(this, 30, value);
+        }
+        get transform_title() {
+            return pb_1.Message.getFieldWithDefault(this, 22, "") as string;
+        }
+        set transform_title(value: string) {
+            pb_1.Message.setField(this, 22, value);
+        }
         get selector_link() {
             return pb_1.Message.getFieldWithDefault(this, 4, "") as string;
         }
         set selector_link(value: string) {
             pb_1.Message.setField(this, 4, value);
         }
+        get selector_link_type() {
+            return pb_1.Message.getFieldWithDefault(this, 16, SelectorType.Css) as SelectorType;
+        }
+        set selector_link_type(value: SelectorType) {
+            pb_1.Message.setField(this, 16, value);
+        }
+        get link_attribute_name() {
+            return pb_1.Message.getFieldWithDefault(this, 35, "") as string;
+        }
+        set link_attribute_name(value: string) {
+            pb_1.Message.setField(this, 35, value);
+        }
+        get transform_link() {
+            return pb_1.Message.getFieldWithDefault(this, 23, "") as string;
+        }
+        set transform_link(value: string) {
+            pb_1.Message.setField(this, 23, value);
+        }
         get selector_description() {
             return pb_1.Message.getFieldWithDefault(this, 5, "") as string;
         }
         set selector_description(value: string) {
             pb_1.Message.setField(this, 5, value);
         }
+        get selector_description_type() {
+            return pb_1.Message.getFieldWithDefault(this, 17, SelectorType.Css) as SelectorType;
+        }
+        set selector_description_type(value: SelectorType) {
+            pb_1.Message.setField(this, 17, value);
+        }
+        get description_extract_from() {
+            return pb_1.Message.getFieldWithDefault(this, 31, ExtractFrom.InnerText) as ExtractFrom;
+        }
+        set description_extract_from(value: ExtractFrom) {
+            pb_1.Message.setField(this, 31, value);
+        }
+        get description_attribute_name() {
+            return pb_1.Message.getFieldWithDefault(this, 32, "") as string;
+        }
+        set description_attribute_name(value: string) {
+            pb_1.Message.setField(this, 32, value);
+        }
+        get transform_description() {
+            return pb_1.Message.getFieldWithDefault(this, 24, "") as string;
+        }
+        set transform_description(value: string) {
+            pb_1.Message.setField(this, 24, value);
+        }
         get selector_author() {
             return pb_1.Message.getFieldWithDefault(this, 6, "") as string;
         }
         set selector_author(value: string) {
             pb_1.Message.setField(this, 6, value);
         }
+        get selector_author_type() {
+            return pb_1.Message.getFieldWithDefault(this, 18, SelectorType.Css) as SelectorType;
+        }
+        set selector_author_type(value: SelectorType) {
+            pb_1.Message.setField(this, 18, value);
+        }
+        get author_extract_from() {
+            return pb_1.Message.getFieldWithDefault(this, 33, ExtractFrom.InnerText) as ExtractFrom;
+        }
+        set author_extract_from(value: ExtractFrom) {
+            pb_1.Message.setField(this, 33, value);
+        }
+        get author_attribute_name() {
+            return pb_1.Message.getFieldWithDefault(this, 34, "") as string;
+        }
+        set author_attribute_name(value: string) {
+            pb_1.Message.setField(this, 34, value);
+        }
+        get author_link_attribute_name() {
+            return pb_1.Message.getFieldWithDefault(this, 41, "") as string;
+        }
+        set author_link_attribute_name(value: string) {
+            pb_1.Message.setField(this, 41, value);
+        }
+        get transform_author() {
+            return pb_1.Message.getFieldWithDefault(this, 25, "") as string;
+        }
+        set transform_author(value: string) {
+            pb_1.Message.setField(this, 25, value);
+        }
         get selector_created() {
             return pb_1.Message.getFieldWithDefault(this, 7, "") as string;
         }
         set selector_created(value: string) {
             pb_1.Message.setField(this, 7, value);
         }
+        get selector_created_type() {
+            return pb_1.Message.getFieldWithDefault(this, 19, SelectorType.Css) as SelectorType;
+        }
+        set selector_created_type(value: SelectorType) {
+            pb_1.Message.setField(this, 19, value);
+        }
         get created_extract_from() {
             return pb_1.Message.getFieldWithDefault(this, 11, ExtractFrom.InnerText) as ExtractFrom;
         }
@@ -120,18 +358,78 @@ export namespace rssalchemy {
         set created_attribute_name(value: string) {
             pb_1.Message.setField(this, 12, value);
         }
+        get date_formats() {
+            return pb_1.Message.getFieldWithDefault(this, 37, "") as string;
+        }
+        set date_formats(value: string) {
+            pb_1.Message.setField(this, 37, value);
+        }
+        get date_languages() {
+            return pb_1.Message.getFieldWithDefault(this, 38, "") as string;
+        }
+        set date_languages(value: string) {
+            pb_1.Message.setField(this, 38, value);
+        }
+        get date_timezone() {
+            return pb_1.Message.getFieldWithDefault(this, 39, "") as string;
+        }
+        set date_timezone(value: string) {
+            pb_1.Message.setField(this, 39, value);
+        }
+        get date_day_first() {
+            return pb_1.Message.getFieldWithDefault(this, 40, false) as boolean;
+        }
+        set date_day_first(value: boolean) {
+            pb_1.Message.setField(this, 40, value);
+        }
+        get transform_created() {
+            return pb_1.Message.getFieldWithDefault(this, 26, "") as string;
+        }
+        set transform_created(value: string) {
+            pb_1.Message.setField(this, 26, value);
+        }
         get selector_content() {
             return pb_1.Message.getFieldWithDefault(this, 8, "") as string;
         }
         set selector_content(value: string) {
             pb_1.Message.setField(this, 8, value);
         }
+        get selector_content_type() {
+            return pb_1.Message.getFieldWithDefault(this, 20, SelectorType.Css) as SelectorType;
+        }
+        set selector_content_type(value: SelectorType) {
+            pb_1.Message.setField(this, 20, value);
+        }
+        get transform_content() {
+            return pb_1.Message.getFieldWithDefault(this, 27, "") as string;
+        }
+        set transform_content(value: string) {
+            pb_1.Message.setField(this, 27, value);
+        }
         get selector_enclosure() {
             return pb_1.Message.getFieldWithDefault(this, 9, "") as string;
         }
         set selector_enclosure(value: string) {
             pb_1.Message.setField(this, 9, value);
         }
+        get selector_enclosure_type() {
+            return pb_1.Message.getFieldWithDefault(this, 21, SelectorType.Css) as SelectorType;
+        }
+        set selector_enclosure_type(value: SelectorType) {
+            pb_1.Message.setField(this, 21, value);
+        }
+        get enclosure_attribute_name() {
+            return pb_1.Message.getFieldWithDefault(this, 36, "") as string;
+        }
+        set enclosure_attribute_name(value: string) {
+            pb_1.Message.setField(this, 36, value);
+        }
+        get transform_enclosure() {
+            return pb_1.Message.getFieldWithDefault(this, 28, "") as string;
+        }
+        set transform_enclosure(value: string) {
+            pb_1.Message.setField(this, 28, value);
+        }
         get cache_lifetime() {
             return pb_1.Message.getFieldWithDefault(this, 10, "") as string;
         }
@@ -140,52 +438,168 @@ export namespace rssalchemy {
         }
         static fromObject(data: {
             url?: string;
+            source_type?: SourceType;
             selector_post?: string;
+            selector_post_type?: SelectorType;
             selector_title?: string;
+            selector_title_type?: SelectorType;
+            title_extract_from?: ExtractFrom;
+            title_attribute_name?: string;
+            transform_title?: string;
             selector_link?: string;
+            selector_link_type?: SelectorType;
+            link_attribute_name?: string;
+            transform_link?: string;
             selector_description?: string;
+            selector_description_type?: SelectorType;
+            description_extract_from?: ExtractFrom;
+            description_attribute_name?: string;
+            transform_description?: string;
             selector_author?: string;
+            selector_author_type?: SelectorType;
+            author_extract_from?: ExtractFrom;
+            author_attribute_name?: string;
+            author_link_attribute_name?: string;
+            transform_author?: string;
             selector_created?: string;
+            selector_created_type?: SelectorType;
             created_extract_from?: ExtractFrom;
             created_attribute_name?: string;
+            date_formats?: string;
+            date_languages?: string;
+            date_timezone?: string;
+            date_day_first?: boolean;
+            transform_created?: string;
             selector_content?: string;
+            selector_content_type?: SelectorType;
+            transform_content?: string;
             selector_enclosure?: string;
+            selector_enclosure_type?: SelectorType;
+            enclosure_attribute_name?: string;
+            transform_enclosure?: string;
             cache_lifetime?: string;
         }): Specs {
             const message = new Specs({});
             if (data.url != null) {
                 message.url = data.url;
             }
+            if (data.source_type != null) {
+                message.source_type = data.source_type;
+            }
             if (data.selector_post != null) {
                 message.selector_post = data.selector_post;
             }
+            if (data.selector_post_type != null) {
+                message.selector_post_type = data.selector_post_type;
+            }
             if (data.selector_title != null) {
                 message.selector_title = data.selector_title;
             }
+            if (data.selector_title_type != null) {
+                message.selector_title_type = data.selector_title_type;
+            }
+            if (data.title_extract_from != null) {
+                message.title_extract_from = data.title_extract_from;
+            }
+            if (data.title_attribute_name != null) {
+                message.title_attribute_name = data.title_attribute_name;
+            }
+            if (data.transform_title != null) {
+                message.transform_title = data.transform_title;
+            }
             if (data.selector_link != null) {
                 message.selector_link = data.selector_link;
             }
+            if (data.selector_link_type != null) {
+                message.selector_link_type = data.selector_link_type;
+            }
+            if (data.link_attribute_name != null) {
+                message.link_attribute_name = data.link_attribute_name;
+            }
+            if (data.transform_link != null) {
+                message.transform_link = data.transform_link;
+            }
             if (data.selector_description != null) {
                 message.selector_description = data.selector_description;
             }
+            if (data.selector_description_type != null) {
+                message.selector_description_type = data.selector_description_type;
+            }
+            if (data.description_extract_from != null) {
+                message.description_extract_from = data.description_extract_from;
+            }
+            if (data.description_attribute_name != null) {
+                message.description_attribute_name = data.description_attribute_name;
+            }
+            if (data.transform_description != null) {
+                message.transform_description = data.transform_description;
+            }
             if (data.selector_author != null) {
                 message.selector_author = data.selector_author;
             }
+            if (data.selector_author_type != null) {
+                message.selector_author_type = data.selector_author_type;
+            }
+            if (data.author_extract_from != null) {
+                message.author_extract_from = data.author_extract_from;
+            }
+            if (data.author_attribute_name != null) {
+                message.author_attribute_name = data.author_attribute_name;
+            }
+            if (data.author_link_attribute_name != null) {
+                message.author_link_attribute_name = data.author_link_attribute_name;
+            }
+            if (data.transform_author != null) {
+                message.transform_author = data.transform_author;
+            }
             if (data.selector_created != null) {
                 message.selector_created = data.selector_created;
             }
+            if (data.selector_created_type != null) {
+                message.selector_created_type = data.selector_created_type;
+            }
             if (data.created_extract_from != null) {
                 message.created_extract_from = data.created_extract_from;
             }
             if (data.created_attribute_name != null) {
                 message.created_attribute_name = data.created_attribute_name;
             }
+            if (data.date_formats != null) {
+                message.date_formats = data.date_formats;
+            }
+            if (data.date_languages != null) {
+                message.date_languages = data.date_languages;
+            }
+            if (data.date_timezone != null) {
+                message.date_timezone = data.date_timezone;
+            }
+            if (data.date_day_first != null) {
+                message.date_day_first = data.date_day_first;
+            }
+            if (data.transform_created != null) {
+                message.transform_created = data.transform_created;
+            }
             if (data.selector_content != null) {
                 message.selector_content = data.selector_content;
             }
+            if (data.selector_content_type != null) {
+                message.selector_content_type = data.selector_content_type;
+            }
+            if (data.transform_content != null) {
+                message.transform_content = data.transform_content;
+            }
             if (data.selector_enclosure != null) {
                 message.selector_enclosure = data.selector_enclosure;
             }
+            if (data.selector_enclosure_type != null) {
+                message.selector_enclosure_type = data.selector_enclosure_type;
+            }
+            if (data.enclosure_attribute_name != null) {
+                message.enclosure_attribute_name = data.enclosure_attribute_name;
+            }
+            if (data.transform_enclosure != null) {
+                message.transform_enclosure = data.transform_enclosure;
+            }
             if (data.cache_lifetime != null) {
                 message.cache_lifetime = data.cache_lifetime;
             }
@@ -194,51 +608,167 @@ export namespace rssalchemy {
         toObject() {
             const data: {
                 url?: string;
+                source_type?: SourceType;
                 selector_post?: string;
+                selector_post_type?: SelectorType;
                 selector_title?: string;
+                selector_title_type?: SelectorType;
+                title_extract_from?: ExtractFrom;
+                title_attribute_name?: string;
+                transform_title?: string;
                 selector_link?: string;
+                selector_link_type?: SelectorType;
+                link_attribute_name?: string;
+                transform_link?: string;
                 selector_description?: string;
+                selector_description_type?: SelectorType;
+                description_extract_from?: ExtractFrom;
+                description_attribute_name?: string;
+                transform_description?: string;
                 selector_author?: string;
+                selector_author_type?: SelectorType;
+                author_extract_from?: ExtractFrom;
+                author_attribute_name?: string;
+                author_link_attribute_name?: string;
+                transform_author?: string;
                 selector_created?: string;
+                selector_created_type?: SelectorType;
                 created_extract_from?: ExtractFrom;
                 created_attribute_name?: string;
+                date_formats?: string;
+                date_languages?: string;
+                date_timezone?: string;
+                date_day_first?: boolean;
+                transform_created?: string;
                 selector_content?: string;
+                selector_content_type?: SelectorType;
+                transform_content?: string;
                 selector_enclosure?: string;
+                selector_enclosure_type?: SelectorType;
+                enclosure_attribute_name?: string;
+                transform_enclosure?: string;
                 cache_lifetime?: string;
             } = {};
             if (this.url != null) {
                 data.url = this.url;
             }
+            if (this.source_type != null) {
+                data.source_type = this.source_type;
+            }
             if (this.selector_post != null) {
                 data.selector_post = this.selector_post;
             }
+            if (this.selector_post_type != null) {
+                data.selector_post_type = this.selector_post_type;
+            }
             if (this.selector_title != null) {
                 data.selector_title = this.selector_title;
             }
+            if (this.selector_title_type != null) {
+                data.selector_title_type = this.selector_title_type;
+            }
+            if (this.title_extract_from != null) {
+                data.title_extract_from = this.title_extract_from;
+            }
+            if (this.title_attribute_name != null) {
+                data.title_attribute_name = this.title_attribute_name;
+            }
+            if (this.transform_title != null) {
+                data.transform_title = this.transform_title;
+            }
             if (this.selector_link != null) {
                 data.selector_link = this.selector_link;
             }
+            if (this.selector_link_type != null) {
+                data.selector_link_type = this.selector_link_type;
+            }
+            if (this.link_attribute_name != null) {
+                data.link_attribute_name = this.link_attribute_name;
+            }
+            if (this.transform_link != null) {
+                data.transform_link = this.transform_link;
+            }
             if (this.selector_description != null) {
                 data.selector_description = this.selector_description;
             }
+            if (this.selector_description_type != null) {
+                data.selector_description_type = this.selector_description_type;
+            }
+            if (this.description_extract_from != null) {
+                data.description_extract_from = this.description_extract_from;
+            }
+            if (this.description_attribute_name != null) {
+                data.description_attribute_name = this.description_attribute_name;
+            }
+            if (this.transform_description != null) {
+                data.transform_description = this.transform_description;
+            }
             if (this.selector_author != null) {
                 data.selector_author = this.selector_author;
             }
+            if (this.selector_author_type != null) {
+                data.selector_author_type = this.selector_author_type;
+            }
+            if (this.author_extract_from != null) {
+                data.author_extract_from = this.author_extract_from;
+            }
+            if (this.author_attribute_name != null) {
+                data.author_attribute_name = this.author_attribute_name;
+            }
+            if (this.author_link_attribute_name != null) {
+                data.author_link_attribute_name = this.author_link_attribute_name;
+            }
+            if (this.transform_author != null) {
+                data.transform_author = this.transform_author;
+            }
             if (this.selector_created != null) {
                 data.selector_created = this.selector_created;
             }
+            if (this.selector_created_type != null) {
+                data.selector_created_type = this.selector_created_type;
+            }
             if (this.created_extract_from != null) {
                 data.created_extract_from = this.created_extract_from;
             }
             if (this.created_attribute_name != null) {
                 data.created_attribute_name = this.created_attribute_name;
             }
+            if (this.date_formats != null) {
+                data.date_formats = this.date_formats;
+            }
+            if (this.date_languages != null) {
+                data.date_languages = this.date_languages;
+            }
+            if (this.date_timezone != null) {
+                data.date_timezone = this.date_timezone;
+            }
+            if (this.date_day_first != null) {
+                data.date_day_first = this.date_day_first;
+            }
+            if (this.transform_created != null) {
+                data.transform_created = this.transform_created;
+            }
             if (this.selector_content != null) {
                 data.selector_content = this.selector_content;
             }
+            if (this.selector_content_type != null) {
+                data.selector_content_type = this.selector_content_type;
+            }
+            if (this.transform_content != null) {
+                data.transform_content = this.transform_content;
+            }
             if (this.selector_enclosure != null) {
                 data.selector_enclosure = this.selector_enclosure;
             }
+            if (this.selector_enclosure_type != null) {
+                data.selector_enclosure_type = this.selector_enclosure_type;
+            }
+            if (this.enclosure_attribute_name != null) {
+                data.enclosure_attribute_name = this.enclosure_attribute_name;
+            }
+            if (this.transform_enclosure != null) {
+                data.transform_enclosure = this.transform_enclosure;
+            }
             if (this.cache_lifetime != null) {
                 data.cache_lifetime = this.cache_lifetime;
             }
@@ -250,26 +780,84 @@ export namespace rssalchemy {
             const writer = w || new pb_1.BinaryWriter();
             if (this.url.length)
                 writer.writeString(1, this.url);
+            if (this.source_type != SourceType.Html)
+                writer.writeEnum(13, this.source_type);
             if (this.selector_post.length)
                 writer.writeString(2, this.selector_post);
+            if (this.selector_post_type != SelectorType.Css)
+                writer.writeEnum(14, this.selector_post_type);
             if (this.selector_title.length)
                 writer.writeString(3, this.selector_title);
+            if (this.selector_title_type != SelectorType.Css)
+                writer.writeEnum(15, this.selector_title_type);
+            if (this.title_extract_from != ExtractFrom.InnerText)
+                writer.writeEnum(29, this.title_extract_from);
+            if (this.title_attribute_name.length)
+                writer.writeString(30, this.title_attribute_name);
+            if (this.transform_title.length)
+                writer.writeString(22, this.transform_title);
             if (this.selector_link.length)
                 writer.writeString(4, this.selector_link);
+            if (this.selector_link_type != SelectorType.Css)
+                writer.writeEnum(16, this.selector_link_type);
+            if (this.link_attribute_name.length)
+                writer.writeString(35, this.link_attribute_name);
+            if (this.transform_link.length)
+                writer.writeString(23, this.transform_link);
             if (this.selector_description.length)
                 writer.writeString(5, this.selector_description);
+            if (this.selector_description_type != SelectorType.Css)
+                writer.writeEnum(17, this.selector_description_type);
+            if (this.description_extract_from != ExtractFrom.InnerText)
+                writer.writeEnum(31, this.description_extract_from);
+            if (this.description_attribute_name.length)
+                writer.writeString(32, this.description_attribute_name);
+            if (this.transform_description.length)
+                writer.writeString(24, this.transform_description);
             if (this.selector_author.length)
                 writer.writeString(6, this.selector_author);
+            if (this.selector_author_type != SelectorType.Css)
+                writer.writeEnum(18, this.selector_author_type);
+            if (this.author_extract_from != ExtractFrom.InnerText)
+                writer.writeEnum(33, this.author_extract_from);
+            if (this.author_attribute_name.length)
+                writer.writeString(34, this.author_attribute_name);
+            if (this.author_link_attribute_name.length)
+                writer.writeString(41, this.author_link_attribute_name);
+            if (this.transform_author.length)
+                writer.writeString(25, this.transform_author);
             if (this.selector_created.length)
                 writer.writeString(7, this.selector_created);
+            if (this.selector_created_type != SelectorType.Css)
+                writer.writeEnum(19, this.selector_created_type);
             if (this.created_extract_from != ExtractFrom.InnerText)
                 writer.writeEnum(11, this.created_extract_from);
             if (this.created_attribute_name.length)
                 writer.writeString(12, this.created_attribute_name);
+            if (this.date_formats.length)
+                writer.writeString(37, this.date_formats);
+            if (this.date_languages.length)
+                writer.writeString(38, this.date_languages);
+            if (this.date_timezone.length)
+                writer.writeString(39, this.date_timezone);
+            if (this.date_day_first != false)
+                writer.writeBool(40, this.date_day_first);
+            if (this.transform_created.length)
+                writer.writeString(26, this.transform_created);
             if (this.selector_content.length)
                 writer.writeString(8, this.selector_content);
+            if (this.selector_content_type != SelectorType.Css)
+                writer.writeEnum(20, this.selector_content_type);
+            if (this.transform_content.length)
+                writer.writeString(27, this.transform_content);
             if (this.selector_enclosure.length)
                 writer.writeString(9, this.selector_enclosure);
+            if (this.selector_enclosure_type != SelectorType.Css)
+                writer.writeEnum(21, this.selector_enclosure_type);
+            if (this.enclosure_attribute_name.length)
+                writer.writeString(36, this.enclosure_attribute_name);
+            if (this.transform_enclosure.length)
+                writer.writeString(28, this.transform_enclosure);
             if (this.cache_lifetime.length)
                 writer.writeString(10, this.cache_lifetime);
             if (!w)
@@ -284,36 +872,123 @@ export namespace rssalchemy {
                     case 1:
                         message.url = reader.readString();
                         break;
+                    case 13:
+                        message.source_type = reader.readEnum();
+                        break;
                     case 2:
                         message.selector_post = reader.readString();
                         break;
+                    case 14:
+                        message.selector_post_type = reader.readEnum();
+                        break;
                     case 3:
                         message.selector_title = reader.readString();
                         break;
+                    case 15:
+                        message.selector_title_type = reader.readEnum();
+                        break;
+                    case 29:
+                        message.title_extract_from = reader.readEnum();
+                        break;
+                    case 30:
+                        message.title_attribute_name = reader.readString();
+                        break;
+                    case 22:
+                        message.transform_title = reader.readString();
+                        break;
                     case 4:
                         message.selector_link = reader.readString();
                         break;
+                    case 16:
+                        message.selector_link_type = reader.readEnum();
+                        break;
+                    case 35:
+                        message.link_attribute_name = reader.readString();
+                        break;
+                    case 23:
+                        message.transform_link = reader.readString();
+                        break;
                     case 5:
                         message.selector_description = reader.readString();
                         break;
+                    case 17:
+                        message.selector_description_type = reader.readEnum();
+                        break;
+                    case 31:
+                        message.description_extract_from = reader.readEnum();
+                        break;
+                    case 32:
+                        message.description_attribute_name = reader.readString();
+                        break;
+                    case 24:
+                        message.transform_description = reader.readString();
+                        break;
                     case 6:
                         message.selector_author = reader.readString();
                         break;
+                    case 18:
+                        message.selector_author_type = reader.readEnum();
+                        break;
+                    case 33:
+                        message.author_extract_from = reader.readEnum();
+                        break;
+                    case 34:
+                        message.author_attribute_name = reader.readString();
+                        break;
+                    case 41:
+                        message.author_link_attribute_name = reader.readString();
+                        break;
+                    case 25:
+                        message.transform_author = reader.readString();
+                        break;
                     case 7:
                         message.selector_created = reader.readString();
                         break;
+                    case 19:
+                        message.selector_created_type = reader.readEnum();
+                        break;
                     case 11:
                         message.created_extract_from = reader.readEnum();
                         break;
                     case 12:
                         message.created_attribute_name = reader.readString();
                         break;
+                    case 37:
+                        message.date_formats = reader.readString();
+                        break;
+                    case 38:
+                        message.date_languages = reader.readString();
+                        break;
+                    case 39:
+                        message.date_timezone = reader.readString();
+                        break;
+                    case 40:
+                        message.date_day_first = reader.readBool();
+                        break;
+                    case 26:
+                        message.transform_created = reader.readString();
+                        break;
                     case 8:
                         message.selector_content = reader.readString();
                         break;
+                    case 20:
+                        message.selector_content_type = reader.readEnum();
+                        break;
+                    case 27:
+                        message.transform_content = reader.readString();
+                        break;
                     case 9:
                         message.selector_enclosure = reader.readString();
                         break;
+                    case 21:
+                        message.selector_enclosure_type = reader.readEnum();
+                        break;
+                    case 36:
+                        message.enclosure_attribute_name = reader.readString();
+                        break;
+                    case 28:
+                        message.transform_enclosure = reader.readString();
+                        break;
                     case 10:
                         message.cache_lifetime = reader.readString();
                         break;
@@ -1,7 +1,11 @@
 import {
+  selectorValidator,
+  validateDateFormats,
+  validateLanguages,
+  validateTimezone,
+  validateTransform,
   validateAttribute,
   validateDuration,
-  validateSelector,
   validateUrl,
   type validator
 } from "@/urlmaker/validators.ts";
@@ -10,25 +14,55 @@ import type {Enum} from "@/common/enum.ts";
 
 export const defaultSpecs = {
   url: '',
+  source_type: rssalchemy.SourceType.Html,
   selector_post: '',
+  selector_post_type: rssalchemy.SelectorType.Css,
   selector_title: '',
+  selector_title_type: rssalchemy.SelectorType.Css,
+  title_extract_from: rssalchemy.ExtractFrom.InnerText,
+  title_attribute_name: '',
+  transform_title: '',
   selector_link: '',
+  selector_link_type: rssalchemy.SelectorType.Css,
+  link_attribute_name: '',
+  transform_link: '',
   selector_description: '',
+  selector_description_type: rssalchemy.SelectorType.Css,
+  description_extract_from: rssalchemy.ExtractFrom.InnerText,
+  description_attribute_name: '',
+  transform_description: '',
   selector_author: '',
+  selector_author_type: rssalchemy.SelectorType.Css,
+  author_extract_from: rssalchemy.ExtractFrom.InnerText,
+  author_attribute_name: '',
+  author_link_attribute_name: '',
+  transform_author: '',
   selector_content: '',
+  selector_content_type: rssalchemy.SelectorType.Css,
+  transform_content: '',
   selector_enclosure: '',
+  selector_enclosure_type: rssalchemy.SelectorType.Css,
+  enclosure_attribute_name: '',
+  transform_enclosure: '',
   selector_created: '',
+  selector_created_type: rssalchemy.SelectorType.Css,
   created_extract_from: rssalchemy.ExtractFrom.InnerText,
   created_attribute_name: '',
+  date_formats: '',
+  date_languages: '',
+  date_timezone: '',
+  date_day_first: false,
+  transform_created: '',
   cache_lifetime: '10m'
 };
 
-export type SpecValue = string | number;
+export type SpecValue = string | number | boolean;
 export type Specs = typeof defaultSpecs;
 
 export enum InputType {
   Url = 'url',
   Text = 'text',
+  Multiline = 'multiline',
   Radio = 'radio'
 }
 
@@ -43,6 +77,92 @@ export interface SpecField {
   show_if?: (specs: Specs) => boolean
 }
 
+const isHtml = (specs: Specs) => specs.source_type !== rssalchemy.SourceType.Json;
+
+// selectorFields returns text input for selector and radio buttons for its type (CSS or XPath)
+function selectorFields(name: keyof Specs, label: string, group?: string): SpecField[] {
+  const typeName = `${name}_type` as keyof Specs;
+  return [
+    {
+      name: name,
+      input_type: InputType.Text,
+      label: `Selector for ${label}`,
+      validate: selectorValidator(typeName),
+      group: group || name,
+    },
+    {
+      name: typeName,
+      input_type: InputType.Radio,
+      enum: [
+        {label: 'CSS', value: rssalchemy.SelectorType.Css},
+        {label: 'XPath', value: rssalchemy.SelectorType.XPath},
+      ],
+      label: 'Selector type',
+      validate: value => Object.values(rssalchemy.SelectorType).includes(value),
+      group: group || name,
+      show_if: specs => isHtml(specs) && !!specs[name],
+    },
+  ];
+}
+
+type ExtractFromPrefix = 'title' | 'description' | 'author' | 'created';
+
+// extractFromFields returns radio buttons for choosing between inner text and attribute, and attribute name input
+function extractFromFields(prefix: ExtractFromPrefix, group?: string): SpecField[] {
+  const selectorName = `selector_${prefix}` as const;
+  const extractFromName = `${prefix}_extract_from` as const;
+  return [
+    {
+      name: extractFromName,
+      input_type: InputType.Radio,
+      enum: [
+        {label: 'Inner Text', value: rssalchemy.ExtractFrom.InnerText},
+        {label: 'Attribute', value: rssalchemy.ExtractFrom.Attribute},
+      ],
+      label: 'Extract from',
+      validate: value => Object.values(rssalchemy.ExtractFrom).includes(value),
+      group: group || selectorName,
+      show_if: specs => !!specs[selectorName] && isHtml(specs),
+    },
+    {
+      name: `${prefix}_attribute_name`,
+      input_type: InputType.Text,
+      label: 'Attribute name',
+      validate: validateAttribute,
+      show_if: specs =>
+        !!specs[selectorName] && isHtml(specs)
+        && specs[extractFromName] === rssalchemy.ExtractFrom.Attribute,
+      group: group || selectorName,
+    },
+  ];
+}
+
+// attributeField returns attribute name input for fields which are always taken from attribute
+function attributeField(
+  name: keyof Specs, selectorName: keyof Specs, defaultAttribute: string, label = 'Attribute name',
+): SpecField {
+  return {
+    name: name,
+    input_type: InputType.Text,
+    label: `${label} (default: ${defaultAttribute})`,
+    validate: validateAttribute,
+    show_if: specs => !!specs[selectorName] && isHtml(specs),
+    group: selectorName,
+  };
+}
+
+// transformField returns optional post-processing steps for field value, see internal/transform
+function transformField(name: keyof Specs, selectorName: keyof Specs, group?: string): SpecField {
+  return {
+    name: name,
+    input_type: InputType.Multiline,
+    label: 'Transform (one step per line: trim, extract /re/, replace /re/ text, prefix text, suffix text, template {author}: {value})',
+    validate: validateTransform,
+    group: group || selectorName,
+    show_if: specs => !!specs[selectorName],
+  };
+}
+
 export const fields: SpecField[] = [
   {
     name: 'url',
@@ -52,77 +172,75 @@ export const fields: SpecField[] = [
     required: true,
   },
   {
-    name: 'selector_post',
-    input_type: InputType.Text,
-    label: 'CSS Selector for post',
-    validate: validateSelector,
-  },
-  {
-    name: 'selector_title',
-    input_type: InputType.Text,
-    label: 'CSS Selector for title',
-    validate: validateSelector,
-  },
-  {
-    name: 'selector_link',
-    input_type: InputType.Text,
-    label: 'CSS Selector for link',
-    validate: validateSelector,
+    name: 'source_type',
+    input_type: InputType.Radio,
+    enum: [
+      {label: 'HTML page (CSS or XPath selectors)', value: rssalchemy.SourceType.Html},
+      {label: 'JSON API (JMESPath expressions)', value: rssalchemy.SourceType.Json},
+    ],
+    label: 'Source type',
+    validate: value => Object.values(rssalchemy.SourceType).includes(value),
   },
+  ...selectorFields('selector_post', 'post'),
+  ...selectorFields('selector_title', 'title'),
+  ...extractFromFields('title'),
+  transformField('transform_title', 'selector_title'),
+  ...selectorFields('selector_link', 'link'),
+  attributeField('link_attribute_name', 'selector_link', 'href'),
+  transformField('transform_link', 'selector_link'),
+  ...selectorFields('selector_description', 'description'),
+  ...extractFromFields('description'),
+  transformField('transform_description', 'selector_description'),
+  ...selectorFields('selector_author', 'author'),
+  ...extractFromFields('author'),
+  attributeField('author_link_attribute_name', 'selector_author', 'href', 'Author link attribute name'),
+  transformField('transform_author', 'selector_author'),
+
+  ...selectorFields('selector_created', 'created date', 'created'),
+  ...extractFromFields('created', 'created'),
   {
-    name: 'selector_description',
-    input_type: InputType.Text,
-    label: 'CSS Selector for description',
-    validate: validateSelector,
+    name: 'date_formats',
+    input_type: InputType.Multiline,
+    label: 'Date formats, one per line (optional; Go layout like 02.01.2006 15:04, strftime like %d/%m/%Y, unix or unix_ms)',
+    validate: validateDateFormats,
+    show_if: specs => !!specs.selector_created,
+    group: 'created',
   },
   {
-    name: 'selector_author',
+    name: 'date_languages',
     input_type: InputType.Text,
-    label: 'CSS Selector for author',
-    validate: validateSelector,
+    label: 'Date languages, comma separated (optional, e.g. en, ru)',
+    validate: validateLanguages,
+    show_if: specs => !!specs.selector_created,
+    group: 'created',
   },
-
   {
-    name: 'selector_created',
+    name: 'date_timezone',
     input_type: InputType.Text,
-    label: 'CSS Selector for created date',
-    validate: validateSelector,
+    label: 'Timezone of dates without explicit offset (optional, e.g. Europe/Berlin)',
+    validate: validateTimezone,
+    show_if: specs => !!specs.selector_created,
     group: 'created',
   },
   {
-    name: 'created_extract_from',
+    name: 'date_day_first',
     input_type: InputType.Radio,
     enum: [
-      {label: 'Inner Text', value: rssalchemy.ExtractFrom.InnerText},
-      {label: 'Attribute', value: rssalchemy.ExtractFrom.Attribute},
+      {label: 'Auto', value: false},
+      {label: 'Day first (DD/MM)', value: true},
     ],
-    label: 'Extract from',
-    validate: value => Object.values(rssalchemy.ExtractFrom).includes(value),
-    group: 'created',
+    label: 'Ambiguous dates',
+    validate: value => typeof value === 'boolean',
     show_if: specs => !!specs.selector_created,
-  },
-  {
-    name: 'created_attribute_name',
-    input_type: InputType.Text,
-    label: 'Attribute name',
-    validate: validateAttribute,
-    show_if: specs =>
-      !!specs.selector_created && specs.created_extract_from === rssalchemy.ExtractFrom.Attribute,
     group: 'created',
   },
+  transformField('transform_created', 'selector_created', 'created'),
 
-  {
-    name: 'selector_content',
-    input_type: InputType.Text,
-    label: 'CSS Selector for content',
-    validate: validateSelector,
-  },
-  {
-    name: 'selector_enclosure',
-    input_type: InputType.Text,
-    label: 'CSS Selector for enclosure (e.g. image url)',
-    validate: validateSelector,
-  },
+  ...selectorFields('selector_content', 'content'),
+  transformField('transform_content', 'selector_content'),
+  ...selectorFields('selector_enclosure', 'enclosure (e.g. image url)'),
+  attributeField('enclosure_attribute_name', 'selector_enclosure', 'src'),
+  transformField('transform_enclosure', 'selector_enclosure'),
   {
     name: 'cache_lifetime',
     input_type: InputType.Text,
@@ -1,7 +1,8 @@
 import {presetPrefix} from "@/urlmaker/index.ts";
-import type {SpecValue} from "@/urlmaker/specs.ts";
+import type {Specs, SpecValue} from "@/urlmaker/specs.ts";
+import {rssalchemy} from "@/urlmaker/proto/specs.ts";
 
-export type validator = (v: SpecValue) => boolean;
+export type validator = (v: SpecValue, specs: Specs) => boolean;
 
 export function validateUrl(s: SpecValue): boolean {
   let url;
@@ -26,6 +27,63 @@ export function validateSelector(s: SpecValue): boolean {
   }
 }
 
+export function validateXPath(s: SpecValue): boolean {
+  try {
+    document.createExpression(s as string);
+    return true;
+  } catch {
+    return false;
+  }
+}
+
+// selectorValidator validates selector according to source type and selector type field
+export function selectorValidator(typeField: keyof Specs): validator {
+  return (s: SpecValue, specs: Specs) => {
+    if (specs.source_type === rssalchemy.SourceType.Json) {
+      // JMESPath expressions are validated by backend
+      return (s as string).trim().length > 0;
+    }
+    if (specs[typeField] === rssalchemy.SelectorType.XPath) {
+      return validateXPath(s);
+    }
+    return validateSelector(s);
+  };
+}
+
+const transformSteps = ['trim', 'extract', 'replace', 'prefix', 'suffix', 'template'];
+
+// validateTransform checks step names only, regexes are validated by backend
+export function validateTransform(s: SpecValue): boolean {
+  return (s as string).split('\n').every(line => {
+    line = line.trimStart();
+    if (line.trim() === '' || line.startsWith('#')) return true;
+    return transformSteps.includes(line.split(' ')[0].trimEnd());
+  });
+}
+
+const strftimeDirectives = 'YymdejHIMSfpbhBaAzZFTRD%';
+
+export function validateDateFormats(s: SpecValue): boolean {
+  return (s as string).split('\n').every(line => {
+    if (line.trim() === '' || line === 'unix' || line === 'unix_ms') return true;
+    const directives = line.match(/%./g) || [];
+    return directives.every(d => strftimeDirectives.includes(d[1])) && !/(^|[^%])(%%)*%$/.test(line);
+  });
+}
+
+export function validateLanguages(s: SpecValue): boolean {
+  return /^\s*[a-zA-Z]{2,3}(-[a-zA-Z]+)?(\s*,\s*[a-zA-Z]{2,3}(-[a-zA-Z]+)?)*\s*$/.test(s as string);
+}
+
+export function validateTimezone(s: SpecValue): boolean {
+  try {
+    new Intl.DateTimeFormat(undefined, {timeZone: s as string});
+    return true;
+  } catch {
+    return false;
+  }
+}
+
 export function validateAttribute(s: SpecValue): boolean {
   return /([^\t\n\f \/>"'=]+)/.test(s as string);
 }
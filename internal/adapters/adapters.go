@@ -3,6 +3,7 @@ package adapters
 import (
 	"context"
 	"fmt"
+	"github.com/egor3f/rssalchemy/internal/models"
 	"time"
 )
 
@@ -11,6 +12,7 @@ type WorkQueue interface {
 }
 
 var ErrKeyNotFound = fmt.Errorf("key not found")
+var ErrKeyExists = fmt.Errorf("key exists")
 
 type Cache interface {
 	Get(key string) (result []byte, ts time.Time, err error)
@@ -20,6 +22,50 @@ type Cache interface {
 type QueueConsumer interface {
 	ConsumeQueue(
 		ctx context.Context,
-		taskFunc func(taskPayload []byte) (cacheKey string, result []byte, err error),
+		taskFunc func(ctx context.Context, taskPayload []byte) (cacheKey string, result []byte, err error),
 	) error
 }
+
+// ApiKeyStore keeps api keys by id (hash of secret) and counts their usage
+type ApiKeyStore interface {
+	GetApiKey(id string) (models.ApiKey, error)
+	PutApiKey(id string, key models.ApiKey) error
+	DeleteApiKey(id string) error
+	ListApiKeys() (map[string]models.ApiKey, error)
+	// IncrUsage increments usage counter of key in period and returns new value
+	IncrUsage(id string, period string) (int64, error)
+}
+
+// LinkStore keeps short links by id
+type LinkStore interface {
+	GetLink(id string) (models.ShortLink, error)
+	// CreateLink returns ErrKeyExists if id is taken
+	CreateLink(id string, link models.ShortLink) error
+	PutLink(id string, link models.ShortLink) error
+}
+
+// QueuedMessage is a task message waiting in queue or dead letter stream
+type QueuedMessage struct {
+	Seq       uint64
+	Key       string
+	Published time.Time
+	InFlight  bool   // delivered to worker, but not acked yet
+	Error     string // dead letters only
+	Payload   []byte
+}
+
+// CacheAdmin lists and removes cached task results
+type CacheAdmin interface {
+	ListCache(ctx context.Context) ([]models.CacheEntry, error)
+	GetCacheEntry(ctx context.Context, key string) (models.CacheEntry, []byte, error)
+	DeleteCache(ctx context.Context, key string) error
+}
+
+// QueueAdmin inspects task queue and tasks which failed in worker
+type QueueAdmin interface {
+	ListTasks(ctx context.Context, limit int) ([]QueuedMessage, error)
+	ListDeadLetters(ctx context.Context, limit int) ([]QueuedMessage, error)
+	// RetryDeadLetter moves dead letter back to task queue
+	RetryDeadLetter(ctx context.Context, seq uint64) error
+	PurgeDeadLetters(ctx context.Context) error
+}
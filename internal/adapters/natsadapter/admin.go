@@ -0,0 +1,226 @@
+package natsadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/labstack/gommon/log"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	cacheIndexBucket = "render_cache_index"
+	// dead letters are kept for inspection and retry, old ones are dropped
+	dlqMaxAge  = 7 * 24 * time.Hour
+	dlqMaxMsgs = 10000
+
+	headerError   = "Rssalchemy-Error"
+	headerTaskSeq = "Rssalchemy-Task-Seq"
+)
+
+func dlqName(streamName string) string {
+	return streamName + "_DLQ"
+}
+
+// deadLetter keeps failed task for admin. Errors are only logged, task is already acked.
+// Request headers of task are dropped: they contain users' cookies, which must not be kept for dlqMaxAge.
+// Retried task runs without them.
+func (na *NatsAdapter) deadLetter(ctx context.Context, msg jetstream.Msg, reason string) {
+	key := strings.TrimPrefix(msg.Subject(), na.streamName+".")
+	dead := nats.NewMsg(fmt.Sprintf("%s.%s", dlqName(na.streamName), key))
+	dead.Data = withoutTaskHeaders(msg.Data())
+	for name, values := range msg.Headers() {
+		dead.Header[name] = values
+	}
+	dead.Header.Set(headerError, reason)
+	if metadata, err := msg.Metadata(); err == nil {
+		dead.Header.Set(headerTaskSeq, strconv.FormatUint(metadata.Sequence.Stream, 10))
+	}
+	if _, err := na.jets.PublishMsg(ctx, dead); err != nil {
+		log.Errorf("publish dead letter %s: %v", key, err)
+	}
+}
+
+// withoutTaskHeaders removes Headers of models.Task payload, other fields are kept as is.
+// Payload which is not json object is returned unchanged.
+func withoutTaskHeaders(payload []byte) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return payload
+	}
+	if _, ok := fields["Headers"]; !ok {
+		return payload
+	}
+	delete(fields, "Headers")
+	stripped, err := json.Marshal(fields)
+	if err != nil {
+		return nil
+	}
+	return stripped
+}
+
+// PutCacheEntry indexes cached result, so it can be found by admin
+func (na *NatsAdapter) PutCacheEntry(entry models.CacheEntry) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal cache entry: %w", err)
+	}
+	if _, err := na.index.Put(context.TODO(), entry.Key, payload); err != nil {
+		return fmt.Errorf("nats: %w", err)
+	}
+	return nil
+}
+
+// ListCache returns indexed entries which are still in cache
+func (na *NatsAdapter) ListCache(ctx context.Context) ([]models.CacheEntry, error) {
+	keys, err := na.index.Keys(ctx)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrNoKeysFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("nats: %w", err)
+	}
+	entries := make([]models.CacheEntry, 0, len(keys))
+	for _, key := range keys {
+		entry, _, err := na.GetCacheEntry(ctx, key)
+		if errors.Is(err, adapters.ErrKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// GetCacheEntry returns index entry and cached payload. Entries cached before index existed have only key.
+func (na *NatsAdapter) GetCacheEntry(ctx context.Context, key string) (models.CacheEntry, []byte, error) {
+	cached, err := na.kv.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return models.CacheEntry{}, nil, adapters.ErrKeyNotFound
+		}
+		return models.CacheEntry{}, nil, fmt.Errorf("nats: %w", err)
+	}
+	entry := models.CacheEntry{Key: key}
+	indexed, err := na.index.Get(ctx, key)
+	if err != nil && !errors.Is(err, jetstream.ErrKeyNotFound) {
+		return models.CacheEntry{}, nil, fmt.Errorf("nats: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(indexed.Value(), &entry); err != nil {
+			return models.CacheEntry{}, nil, fmt.Errorf("unmarshal cache entry %s: %w", key, err)
+		}
+	}
+	entry.Created = cached.Created()
+	entry.Size = len(cached.Value())
+	return entry, cached.Value(), nil
+}
+
+func (na *NatsAdapter) DeleteCache(ctx context.Context, key string) error {
+	if _, err := na.kv.Get(ctx, key); err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return adapters.ErrKeyNotFound
+		}
+		return fmt.Errorf("nats: %w", err)
+	}
+	if err := na.kv.Delete(ctx, key); err != nil {
+		return fmt.Errorf("nats: %w", err)
+	}
+	if err := na.index.Delete(ctx, key); err != nil {
+		return fmt.Errorf("nats: %w", err)
+	}
+	return nil
+}
+
+// ListTasks returns tasks from queue in order, in-flight ones are delivered to worker but not finished yet
+func (na *NatsAdapter) ListTasks(ctx context.Context, limit int) ([]adapters.QueuedMessage, error) {
+	var delivered uint64
+	cons, err := na.jstream.Consumer(ctx, "worker")
+	if err != nil && !errors.Is(err, jetstream.ErrConsumerNotFound) {
+		return nil, fmt.Errorf("consumer info: %w", err)
+	}
+	if err == nil {
+		delivered = cons.CachedInfo().Delivered.Stream
+	}
+	messages, err := listMessages(ctx, na.jstream, na.streamName, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range messages {
+		// work queue deletes acked messages, so every delivered message left is in progress
+		messages[i].InFlight = messages[i].Seq <= delivered
+	}
+	return messages, nil
+}
+
+func (na *NatsAdapter) ListDeadLetters(ctx context.Context, limit int) ([]adapters.QueuedMessage, error) {
+	return listMessages(ctx, na.dlq, dlqName(na.streamName), limit)
+}
+
+func (na *NatsAdapter) RetryDeadLetter(ctx context.Context, seq uint64) error {
+	raw, err := na.dlq.GetMsg(ctx, seq)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrMsgNotFound) {
+			return adapters.ErrKeyNotFound
+		}
+		return fmt.Errorf("get dead letter: %w", err)
+	}
+	key := strings.TrimPrefix(raw.Subject, dlqName(na.streamName)+".")
+	msg := nats.NewMsg(fmt.Sprintf("%s.%s", na.streamName, key))
+	msg.Data = raw.Data
+	for name, values := range raw.Header {
+		if name != headerError && name != headerTaskSeq {
+			msg.Header[name] = values
+		}
+	}
+	if _, err := na.jets.PublishMsg(ctx, msg); err != nil {
+		return fmt.Errorf("nats publish: %w", err)
+	}
+	if err := na.dlq.DeleteMsg(ctx, seq); err != nil {
+		return fmt.Errorf("delete dead letter: %w", err)
+	}
+	return nil
+}
+
+func (na *NatsAdapter) PurgeDeadLetters(ctx context.Context) error {
+	if err := na.dlq.Purge(ctx); err != nil {
+		return fmt.Errorf("purge dead letters: %w", err)
+	}
+	return nil
+}
+
+// listMessages reads up to limit messages from the beginning of stream
+func listMessages(ctx context.Context, stream jetstream.Stream, subjectPrefix string, limit int) ([]adapters.QueuedMessage, error) {
+	info, err := stream.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("stream info: %w", err)
+	}
+	var messages []adapters.QueuedMessage
+	for seq := info.State.FirstSeq; seq <= info.State.LastSeq && len(messages) < limit; seq++ {
+		raw, err := stream.GetMsg(ctx, seq)
+		if errors.Is(err, jetstream.ErrMsgNotFound) {
+			// acked or deleted
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get msg %d: %w", seq, err)
+		}
+		messages = append(messages, adapters.QueuedMessage{
+			Seq:       raw.Sequence,
+			Key:       strings.TrimPrefix(raw.Subject, subjectPrefix+"."),
+			Published: raw.Time,
+			Error:     raw.Header.Get(headerError),
+			Payload:   raw.Data,
+		})
+	}
+	return messages, nil
+}
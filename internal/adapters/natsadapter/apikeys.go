@@ -0,0 +1,145 @@
+package natsadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"strconv"
+	"time"
+)
+
+const (
+	apiKeysBucket  = "api_keys"
+	apiUsageBucket = "api_usage"
+	// usage counters are daily, so two days are enough to never lose current period
+	apiUsageTTL = 48 * time.Hour
+	// attempts of optimistic counter update under concurrent requests
+	maxIncrAttempts = 10
+)
+
+type ApiKeyStore struct {
+	keys  jetstream.KeyValue
+	usage jetstream.KeyValue
+}
+
+func NewApiKeyStore(natsc *nats.Conn) (*ApiKeyStore, error) {
+	jets, err := jetstream.New(natsc)
+	if err != nil {
+		return nil, fmt.Errorf("create jetstream: %w", err)
+	}
+	keys, err := jets.CreateOrUpdateKeyValue(context.TODO(), jetstream.KeyValueConfig{
+		Bucket: apiKeysBucket,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create api keys kv: %w", err)
+	}
+	usage, err := jets.CreateOrUpdateKeyValue(context.TODO(), jetstream.KeyValueConfig{
+		Bucket: apiUsageBucket,
+		TTL:    apiUsageTTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create api usage kv: %w", err)
+	}
+	return &ApiKeyStore{keys: keys, usage: usage}, nil
+}
+
+func (s *ApiKeyStore) GetApiKey(id string) (models.ApiKey, error) {
+	entry, err := s.keys.Get(context.TODO(), id)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return models.ApiKey{}, adapters.ErrKeyNotFound
+		}
+		return models.ApiKey{}, fmt.Errorf("nats: %w", err)
+	}
+	var key models.ApiKey
+	if err := json.Unmarshal(entry.Value(), &key); err != nil {
+		return models.ApiKey{}, fmt.Errorf("unmarshal api key: %w", err)
+	}
+	return key, nil
+}
+
+func (s *ApiKeyStore) PutApiKey(id string, key models.ApiKey) error {
+	payload, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("marshal api key: %w", err)
+	}
+	if _, err := s.keys.Put(context.TODO(), id, payload); err != nil {
+		return fmt.Errorf("nats: %w", err)
+	}
+	return nil
+}
+
+func (s *ApiKeyStore) DeleteApiKey(id string) error {
+	if _, err := s.keys.Get(context.TODO(), id); err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return adapters.ErrKeyNotFound
+		}
+		return fmt.Errorf("nats: %w", err)
+	}
+	if err := s.keys.Delete(context.TODO(), id); err != nil {
+		return fmt.Errorf("nats: %w", err)
+	}
+	return nil
+}
+
+func (s *ApiKeyStore) ListApiKeys() (map[string]models.ApiKey, error) {
+	result := make(map[string]models.ApiKey)
+	ids, err := s.keys.Keys(context.TODO())
+	if err != nil {
+		if errors.Is(err, jetstream.ErrNoKeysFound) {
+			return result, nil
+		}
+		return nil, fmt.Errorf("nats: %w", err)
+	}
+	for _, id := range ids {
+		key, err := s.GetApiKey(id)
+		if errors.Is(err, adapters.ErrKeyNotFound) {
+			// deleted concurrently
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result[id] = key
+	}
+	return result, nil
+}
+
+func (s *ApiKeyStore) IncrUsage(id string, period string) (int64, error) {
+	kvKey := fmt.Sprintf("%s.%s", id, period)
+	for range maxIncrAttempts {
+		entry, err := s.usage.Get(context.TODO(), kvKey)
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			_, err = s.usage.Create(context.TODO(), kvKey, []byte("1"))
+			if errors.Is(err, jetstream.ErrKeyExists) {
+				continue
+			}
+			if err != nil {
+				return 0, fmt.Errorf("nats: %w", err)
+			}
+			return 1, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("nats: %w", err)
+		}
+		count, err := strconv.ParseInt(string(entry.Value()), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid usage counter %s: %w", kvKey, err)
+		}
+		count++
+		_, err = s.usage.Update(context.TODO(), kvKey, []byte(strconv.FormatInt(count, 10)), entry.Revision())
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("nats: %w", err)
+		}
+		return count, nil
+	}
+	return 0, fmt.Errorf("usage counter %s: too many concurrent updates", kvKey)
+}
@@ -0,0 +1,72 @@
+package natsadapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const linksBucket = "short_links"
+
+type LinkStore struct {
+	links jetstream.KeyValue
+}
+
+func NewLinkStore(natsc *nats.Conn) (*LinkStore, error) {
+	jets, err := jetstream.New(natsc)
+	if err != nil {
+		return nil, fmt.Errorf("create jetstream: %w", err)
+	}
+	links, err := jets.CreateOrUpdateKeyValue(context.TODO(), jetstream.KeyValueConfig{
+		Bucket: linksBucket,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create short links kv: %w", err)
+	}
+	return &LinkStore{links: links}, nil
+}
+
+func (s *LinkStore) GetLink(id string) (models.ShortLink, error) {
+	entry, err := s.links.Get(context.TODO(), id)
+	if err != nil {
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			return models.ShortLink{}, adapters.ErrKeyNotFound
+		}
+		return models.ShortLink{}, fmt.Errorf("nats: %w", err)
+	}
+	var link models.ShortLink
+	if err := json.Unmarshal(entry.Value(), &link); err != nil {
+		return models.ShortLink{}, fmt.Errorf("unmarshal short link: %w", err)
+	}
+	return link, nil
+}
+
+func (s *LinkStore) CreateLink(id string, link models.ShortLink) error {
+	payload, err := json.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("marshal short link: %w", err)
+	}
+	if _, err := s.links.Create(context.TODO(), id, payload); err != nil {
+		if errors.Is(err, jetstream.ErrKeyExists) {
+			return adapters.ErrKeyExists
+		}
+		return fmt.Errorf("nats: %w", err)
+	}
+	return nil
+}
+
+func (s *LinkStore) PutLink(id string, link models.ShortLink) error {
+	payload, err := json.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("marshal short link: %w", err)
+	}
+	if _, err := s.links.Put(context.TODO(), id, payload); err != nil {
+		return fmt.Errorf("nats: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package natsadapter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"time"
+)
+
+const queueDepthInterval = 15 * time.Second
+
+var (
+	enqueuedInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "rssalchemy_queue_enqueued_in_flight",
+		Help: "Tasks enqueued by this process and waiting for result.",
+	})
+	consumedInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "rssalchemy_queue_consumed_in_flight",
+		Help: "Tasks being processed by this worker.",
+	})
+	queueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "rssalchemy_queue_depth",
+		Help: "Tasks waiting in queue, polled periodically.",
+	})
+)
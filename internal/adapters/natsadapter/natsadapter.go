@@ -5,17 +5,28 @@ import (
 	"errors"
 	"fmt"
 	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/egor3f/rssalchemy/internal/tracing"
 	"github.com/labstack/gommon/log"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"math"
 	"sync"
 	"time"
 )
 
+var tracer = otel.Tracer("github.com/egor3f/rssalchemy/internal/adapters/natsadapter")
+
 type NatsAdapter struct {
+	natsc      *nats.Conn
 	jets       jetstream.JetStream
 	jstream    jetstream.Stream
+	dlq        jetstream.Stream
 	kv         jetstream.KeyValue
+	index      jetstream.KeyValue
 	streamName string
 
 	runningMu sync.Mutex
@@ -30,6 +41,7 @@ func New(natsc *nats.Conn, streamName string) (*NatsAdapter, error) {
 		return nil, fmt.Errorf("stream name is empty")
 	}
 	na.streamName = streamName
+	na.natsc = natsc
 
 	na.jets, err = jetstream.New(natsc)
 	if err != nil {
@@ -46,6 +58,19 @@ func New(natsc *nats.Conn, streamName string) (*NatsAdapter, error) {
 		return nil, fmt.Errorf("create js stream: %w", err)
 	}
 
+	na.dlq, err = na.jets.CreateOrUpdateStream(context.TODO(), jetstream.StreamConfig{
+		Name:        dlqName(streamName),
+		Subjects:    []string{fmt.Sprintf("%s.>", dlqName(streamName))},
+		Retention:   jetstream.LimitsPolicy,
+		MaxAge:      dlqMaxAge,
+		MaxMsgs:     dlqMaxMsgs,
+		Discard:     jetstream.DiscardOld,
+		AllowDirect: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create dead letter stream: %w", err)
+	}
+
 	na.kv, err = na.jets.CreateKeyValue(context.TODO(), jetstream.KeyValueConfig{
 		Bucket: "render_cache",
 	})
@@ -53,15 +78,32 @@ func New(natsc *nats.Conn, streamName string) (*NatsAdapter, error) {
 		return nil, fmt.Errorf("create nats kv: %w", err)
 	}
 
+	na.index, err = na.jets.CreateOrUpdateKeyValue(context.TODO(), jetstream.KeyValueConfig{
+		Bucket: cacheIndexBucket,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create cache index kv: %w", err)
+	}
+
 	na.running = make(map[string]struct{})
 
 	return &na, nil
 }
 
-func (na *NatsAdapter) Enqueue(ctx context.Context, key string, payload []byte) ([]byte, error) {
+func (na *NatsAdapter) Enqueue(ctx context.Context, key string, payload []byte) (result []byte, errRet error) {
+	ctx, span := tracer.Start(ctx, "Enqueue", trace.WithSpanKind(trace.SpanKindProducer))
+	span.SetAttributes(attribute.String("rssalchemy.task_key", key))
+	defer func() {
+		if errRet != nil {
+			span.SetStatus(codes.Error, errRet.Error())
+		}
+		span.End()
+	}()
+
 	// prevent resubmitting already running task
 	na.runningMu.Lock()
 	_, alreadyRunning := na.running[key]
+	span.SetAttributes(attribute.Bool("rssalchemy.already_running", alreadyRunning))
 	na.running[key] = struct{}{}
 	na.runningMu.Unlock()
 	defer func() {
@@ -70,6 +112,9 @@ func (na *NatsAdapter) Enqueue(ctx context.Context, key string, payload []byte)
 		na.runningMu.Unlock()
 	}()
 
+	enqueuedInFlight.Inc()
+	defer enqueuedInFlight.Dec()
+
 	watcher, err := na.kv.Watch(ctx, key)
 	if err != nil {
 		return nil, fmt.Errorf("nats watch failed: %w", err)
@@ -81,6 +126,10 @@ func (na *NatsAdapter) Enqueue(ctx context.Context, key string, payload []byte)
 		select {
 		case upd := <-watcher.Updates():
 			if upd != nil {
+				if upd.Operation() != jetstream.KeyValuePut {
+					// purged by admin
+					continue
+				}
 				if !taskEnqueued {
 					// old value from cache, skipping
 					continue
@@ -94,11 +143,10 @@ func (na *NatsAdapter) Enqueue(ctx context.Context, key string, payload []byte)
 				continue
 			}
 			log.Infof("sending task to queue: %s", key)
-			_, err = na.jets.Publish(
-				ctx,
-				fmt.Sprintf("%s.%s", na.streamName, key),
-				payload,
-			)
+			msg := nats.NewMsg(fmt.Sprintf("%s.%s", na.streamName, key))
+			msg.Data = payload
+			tracing.InjectNats(ctx, msg.Header)
+			_, err = na.jets.PublishMsg(ctx, msg)
 			if err != nil {
 				return nil, fmt.Errorf("nats publish error: %v", err)
 			}
@@ -128,9 +176,47 @@ func (na *NatsAdapter) Set(key string, payload []byte) error {
 	return nil
 }
 
+// Ping checks nats connection and jetstream availability
+func (na *NatsAdapter) Ping(ctx context.Context) error {
+	if status := na.natsc.Status(); status != nats.CONNECTED {
+		return fmt.Errorf("nats connection is %s", status)
+	}
+	if _, err := na.jets.AccountInfo(ctx); err != nil {
+		return fmt.Errorf("jetstream: %w", err)
+	}
+	return nil
+}
+
+// QueueDepth returns number of tasks waiting in stream, NaN if stream info is not available
+func (na *NatsAdapter) QueueDepth() float64 {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	info, err := na.jstream.Info(ctx)
+	if err != nil {
+		log.Errorf("stream info: %v", err)
+		return math.NaN()
+	}
+	return float64(info.State.Msgs)
+}
+
+// PollQueueDepth updates queue depth metric every queueDepthInterval until context is cancelled.
+// Stream info is a request to server, so it's not made on every scrape.
+func (na *NatsAdapter) PollQueueDepth(ctx context.Context) {
+	ticker := time.NewTicker(queueDepthInterval)
+	defer ticker.Stop()
+	for {
+		queueDepth.Set(na.QueueDepth())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (na *NatsAdapter) ConsumeQueue(
 	ctx context.Context,
-	taskFunc func(taskPayload []byte) (cacheKey string, result []byte, err error),
+	taskFunc func(ctx context.Context, taskPayload []byte) (cacheKey string, result []byte, err error),
 ) error {
 	cons, err := na.jstream.CreateOrUpdateConsumer(ctx, jetstream.ConsumerConfig{
 		Durable: "worker",
@@ -153,19 +239,32 @@ func (na *NatsAdapter) ConsumeQueue(
 		defer func() {
 			if err := recover(); err != nil {
 				log.Errorf("recovered panic from consumer: %v", err)
+				na.deadLetter(ctx, msg, fmt.Sprintf("panic: %v", err))
 				if err := msg.Term(); err != nil {
 					log.Errorf("term in recover: %v", err)
 				}
 			}
 		}()
-		cacheKey, resultPayload, taskErr := taskFunc(msg.Data())
+		consumedInFlight.Inc()
+		defer consumedInFlight.Dec()
+
+		taskCtx, span := tracer.Start(
+			tracing.ExtractNats(ctx, msg.Headers()),
+			"ConsumeQueue",
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(attribute.Int64("rssalchemy.stream_seq", int64(seq))),
+		)
+		defer span.End()
+		cacheKey, resultPayload, taskErr := taskFunc(taskCtx, msg.Data())
 
 		if err := msg.DoubleAck(ctx); err != nil {
 			log.Errorf("double ack seq=%d: %v", seq, err)
 		}
 
 		if taskErr != nil {
+			span.SetStatus(codes.Error, taskErr.Error())
 			log.Errorf("taskFunc seq=%d error: %v", seq, taskErr)
+			na.deadLetter(ctx, msg, taskErr.Error())
 			return
 		}
 
@@ -0,0 +1,200 @@
+package natsadapter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runNatsServer starts embedded nats-server with jetstream in temp dir and connects to it
+func runNatsServer(t *testing.T) *nats.Conn {
+	t.Helper()
+	ns, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+	ns.Start()
+	t.Cleanup(func() {
+		ns.Shutdown()
+		ns.WaitForShutdown()
+	})
+	require.True(t, ns.ReadyForConnections(10*time.Second), "nats-server didn't start")
+
+	natsc, err := nats.Connect(ns.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(natsc.Close)
+	return natsc
+}
+
+func newTestAdapter(t *testing.T) *NatsAdapter {
+	t.Helper()
+	na, err := New(runNatsServer(t), "TEST_TASKS")
+	require.NoError(t, err)
+	return na
+}
+
+// consume runs ConsumeQueue until the end of test. Payload of test tasks is their cache key.
+func consume(t *testing.T, na *NatsAdapter, taskFunc func(ctx context.Context, payload []byte) ([]byte, error)) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- na.ConsumeQueue(ctx, func(ctx context.Context, payload []byte) (string, []byte, error) {
+			result, err := taskFunc(ctx, payload)
+			return string(payload), result, err
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+}
+
+func testContext(t *testing.T, timeout time.Duration) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestEnqueueResult(t *testing.T) {
+	na := newTestAdapter(t)
+	consume(t, na, func(_ context.Context, payload []byte) ([]byte, error) {
+		return []byte("result of " + string(payload)), nil
+	})
+
+	result, err := na.Enqueue(testContext(t, 10*time.Second), "task1", []byte("task1"))
+	require.NoError(t, err)
+	assert.Equal(t, "result of task1", string(result))
+
+	cached, _, err := na.Get("task1")
+	require.NoError(t, err)
+	assert.Equal(t, "result of task1", string(cached))
+	assert.Equal(t, float64(0), na.QueueDepth(), "task must be acked")
+}
+
+func TestEnqueueSkipsStaleCache(t *testing.T) {
+	na := newTestAdapter(t)
+	require.NoError(t, na.Set("task1", []byte("stale")))
+	consume(t, na, func(_ context.Context, _ []byte) ([]byte, error) {
+		return []byte("fresh"), nil
+	})
+
+	result, err := na.Enqueue(testContext(t, 10*time.Second), "task1", []byte("task1"))
+	require.NoError(t, err)
+	assert.Equal(t, "fresh", string(result))
+}
+
+func TestEnqueueAlreadyRunning(t *testing.T) {
+	na := newTestAdapter(t)
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	consume(t, na, func(_ context.Context, _ []byte) ([]byte, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return []byte("result"), nil
+	})
+
+	ctx := testContext(t, 10*time.Second)
+	var wg sync.WaitGroup
+	results := make([][]byte, 2)
+	errs := make([]error, 2)
+	enqueue := func(i int) {
+		defer wg.Done()
+		results[i], errs[i] = na.Enqueue(ctx, "task1", []byte("task1"))
+	}
+	wg.Add(2)
+	go enqueue(0)
+	<-started
+	// second request comes while task is running, it must wait for the same result instead of publishing
+	go enqueue(1)
+	time.Sleep(200 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := range results {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "result", string(results[i]))
+	}
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestEnqueueCancelled(t *testing.T) {
+	na := newTestAdapter(t)
+	// no consumer, so result never comes
+
+	_, err := na.Enqueue(testContext(t, 300*time.Millisecond), "task1", []byte("task1"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	na.runningMu.Lock()
+	assert.Empty(t, na.running, "cancelled task must not block resubmitting")
+	na.runningMu.Unlock()
+	assert.Equal(t, float64(1), na.QueueDepth(), "task stays in queue for worker")
+}
+
+func TestConsumeErrors(t *testing.T) {
+	na := newTestAdapter(t)
+	consume(t, na, func(_ context.Context, payload []byte) ([]byte, error) {
+		switch string(payload) {
+		case "failing":
+			return nil, errors.New("no posts on page")
+		case "panicking":
+			panic("selector bug")
+		}
+		if strings.Contains(string(payload), "Cookie") {
+			return nil, errors.New("login required")
+		}
+		return []byte("ok"), nil
+	})
+
+	// failed task doesn't produce result, so client waits until timeout
+	_, err := na.Enqueue(testContext(t, time.Second), "failing", []byte("failing"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = na.Enqueue(testContext(t, time.Second), "panicking", []byte("panicking"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// worker survives and processes next tasks
+	result, err := na.Enqueue(testContext(t, 10*time.Second), "good", []byte("good"))
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(result))
+
+	dead, err := na.ListDeadLetters(testContext(t, 5*time.Second), 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 2)
+	assert.Equal(t, "failing", string(dead[0].Payload))
+	assert.Equal(t, "no posts on page", dead[0].Error)
+	assert.Equal(t, "panicking", string(dead[1].Payload))
+	assert.Contains(t, dead[1].Error, "selector bug")
+	assert.Equal(t, float64(0), na.QueueDepth(), "failed tasks are not redelivered")
+
+	// cookies of failed task are not stored in dead letters
+	task := `{"URL": "https://example.com/", "Headers": {"Cookie": "session=secret"}}`
+	_, err = na.Enqueue(testContext(t, time.Second), "with-cookies", []byte(task))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Eventually(t, func() bool {
+		dead, err = na.ListDeadLetters(testContext(t, 5*time.Second), 10)
+		return err == nil && len(dead) == 3
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.JSONEq(t, `{"URL": "https://example.com/"}`, string(dead[2].Payload))
+	assert.Equal(t, "login required", dead[2].Error)
+
+	_, _, err = na.Get("failing")
+	assert.ErrorIs(t, err, adapters.ErrKeyNotFound)
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"bytes"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"net/url"
+	"strings"
+)
+
+// redactedQueryParams are secrets which must not get to access logs
+var redactedQueryParams = []string{apiKeyQueryParam, signatureQueryParam}
+
+// accessLogFormat is echo default format with uri replaced by redacted one
+const accessLogFormat = `{"time":"${time_rfc3339_nano}","id":"${id}","remote_ip":"${remote_ip}",` +
+	`"host":"${host}","method":"${method}","uri":"${custom}","user_agent":"${user_agent}",` +
+	`"status":${status},"error":"${error}","latency":${latency},"latency_human":"${latency_human}"` +
+	`,"bytes_in":${bytes_in},"bytes_out":${bytes_out}}` + "\n"
+
+// AccessLogger is echo request logger which doesn't log api keys and signatures of query params
+func AccessLogger() echo.MiddlewareFunc {
+	return middleware.LoggerWithConfig(middleware.LoggerConfig{
+		Format: accessLogFormat,
+		CustomTagFunc: func(c echo.Context, buf *bytes.Buffer) (int, error) {
+			return buf.WriteString(redactURI(c.Request().RequestURI))
+		},
+	})
+}
+
+// redactURI replaces values of redactedQueryParams, other params are kept as is
+func redactURI(uri string) string {
+	path, query, found := strings.Cut(uri, "?")
+	if !found {
+		return uri
+	}
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		name, _, _ := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil {
+			name = unescaped
+		}
+		for _, redacted := range redactedQueryParams {
+			if name == redacted {
+				params[i] = redacted + "=REDACTED"
+			}
+		}
+	}
+	return path + "?" + strings.Join(params, "&")
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"bytes"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestRedactURI(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  string
+		want string
+	}{
+		{"no query", "/api/v1/render/1:abc", "/api/v1/render/1:abc"},
+		{"no secrets", "/api/v1/render/1:abc?a=1&b=2", "/api/v1/render/1:abc?a=1&b=2"},
+		{"key", "/api/v1/render/1:abc?key=secret", "/api/v1/render/1:abc?key=REDACTED"},
+		{"key and sig keep order", "/x?a=1&sig=s1&key=k1&b=2", "/x?a=1&sig=REDACTED&key=REDACTED&b=2"},
+		{"escaped name", "/x?%6Bey=secret", "/x?key=REDACTED"},
+		{"repeated", "/x?key=a&key=b", "/x?key=REDACTED&key=REDACTED"},
+		{"similar name", "/x?keys=1&monkey=2", "/x?keys=1&monkey=2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactURI(tt.uri))
+		})
+	}
+}
+
+func TestAccessLogger(t *testing.T) {
+	var out bytes.Buffer
+	e := echo.New()
+	e.Use(AccessLogger())
+	e.GET("/feed", func(c echo.Context) error { return c.NoContent(200) })
+	// logger middleware writes to echo logger output
+	e.Logger.SetOutput(&out)
+
+	doRequest(e, http.MethodGet, "/feed?key=secret&sig=signature&x=1", "", nil)
+	assert.Contains(t, out.String(), `"uri":"/feed?key=REDACTED&sig=REDACTED&x=1"`)
+	assert.NotContains(t, out.String(), "secret")
+	assert.NotContains(t, out.String(), "signature")
+}
@@ -0,0 +1,385 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/egor3f/rssalchemy/internal/limiter"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultAdminListLimit = 100
+	maxAdminListLimit     = 1000
+)
+
+// AdminConfig provides backends of admin api. All are optional, endpoints of missing ones respond 501.
+type AdminConfig struct {
+	Cache   adapters.CacheAdmin
+	Queue   adapters.QueueAdmin
+	Limiter limiter.Inspector
+}
+
+func (h *Handler) setupAdminRoutes(admin *echo.Group) {
+	admin.GET("/keys", h.handleListApiKeys)
+	admin.POST("/keys", h.handleCreateApiKey)
+	admin.DELETE("/keys/:id", h.handleDeleteApiKey)
+
+	admin.GET("/cache", h.handleListCache)
+	admin.DELETE("/cache", h.handlePurgeCache)
+	admin.POST("/cache/refresh", h.handleRefreshCache)
+	admin.GET("/cache/:key", h.handleGetCache)
+	admin.DELETE("/cache/:key", h.handleDeleteCache)
+
+	admin.GET("/tasks", h.handleListTasks)
+	admin.GET("/dlq", h.handleListDeadLetters)
+	admin.DELETE("/dlq", h.handlePurgeDeadLetters)
+	admin.POST("/dlq/:seq/retry", h.handleRetryDeadLetter)
+
+	admin.GET("/limiter", h.handleLimiterStates)
+	admin.DELETE("/limiter/:key", h.handleResetLimiter)
+}
+
+type cacheFilter struct {
+	domain  string
+	specKey string
+	all     bool
+}
+
+// parseCacheFilter reads domain (matches subdomains too) or specs (encoded, like in render url) params
+func (h *Handler) parseCacheFilter(c echo.Context) (cacheFilter, error) {
+	f := cacheFilter{
+		domain: strings.TrimSuffix(strings.ToLower(c.QueryParam("domain")), "."),
+		all:    c.QueryParam("all") == "true",
+	}
+	if specsParam := c.QueryParam("specs"); specsParam != "" {
+		specs, err := h.decodeSpecs(specsParam)
+		if err != nil {
+			return cacheFilter{}, echo.NewHTTPError(400, fmt.Errorf("decode specs: %w", err))
+		}
+		task, err := taskFromSpecs(specs)
+		if err != nil {
+			return cacheFilter{}, echo.NewHTTPError(400, err.Error())
+		}
+		f.specKey = task.SpecKey()
+	}
+	return f, nil
+}
+
+func (f cacheFilter) empty() bool {
+	return f.domain == "" && f.specKey == ""
+}
+
+func (f cacheFilter) match(entry models.CacheEntry) bool {
+	if f.specKey != "" && entry.SpecKey != f.specKey {
+		return false
+	}
+	if f.domain != "" && entry.Domain != f.domain && !strings.HasSuffix(entry.Domain, "."+f.domain) {
+		return false
+	}
+	return true
+}
+
+func (h *Handler) filteredCache(c echo.Context, f cacheFilter) ([]models.CacheEntry, error) {
+	entries, err := h.admin.Cache.ListCache(c.Request().Context())
+	if err != nil {
+		return nil, echo.NewHTTPError(500, fmt.Errorf("list cache: %w", err))
+	}
+	var result []models.CacheEntry
+	for _, entry := range entries {
+		if f.match(entry) {
+			result = append(result, entry)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Created.After(result[j].Created)
+	})
+	return result, nil
+}
+
+func (h *Handler) handleListCache(c echo.Context) error {
+	if h.admin.Cache == nil {
+		return echo.NewHTTPError(501, "cache admin is not configured")
+	}
+	f, err := h.parseCacheFilter(c)
+	if err != nil {
+		return err
+	}
+	entries, err := h.filteredCache(c, f)
+	if err != nil {
+		return err
+	}
+	if entries == nil {
+		entries = []models.CacheEntry{}
+	}
+	return c.JSON(200, entries)
+}
+
+type cacheEntryResponse struct {
+	models.CacheEntry
+	Result json.RawMessage `json:"result"`
+}
+
+func (h *Handler) handleGetCache(c echo.Context) error {
+	if h.admin.Cache == nil {
+		return echo.NewHTTPError(501, "cache admin is not configured")
+	}
+	entry, payload, err := h.admin.Cache.GetCacheEntry(c.Request().Context(), c.Param("key"))
+	if errors.Is(err, adapters.ErrKeyNotFound) {
+		return echo.NewHTTPError(404, "cache entry not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("get cache entry: %w", err))
+	}
+	return c.JSON(200, cacheEntryResponse{CacheEntry: entry, Result: payload})
+}
+
+func (h *Handler) handleDeleteCache(c echo.Context) error {
+	if h.admin.Cache == nil {
+		return echo.NewHTTPError(501, "cache admin is not configured")
+	}
+	key := c.Param("key")
+	err := h.admin.Cache.DeleteCache(c.Request().Context(), key)
+	if errors.Is(err, adapters.ErrKeyNotFound) {
+		return echo.NewHTTPError(404, "cache entry not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("delete cache entry: %w", err))
+	}
+	log.Infof("admin deleted cache entry key=%s", key)
+	return c.NoContent(204)
+}
+
+type purgeResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// handlePurgeCache deletes entries matching filter. Filter is required, or all=true to purge everything.
+func (h *Handler) handlePurgeCache(c echo.Context) error {
+	if h.admin.Cache == nil {
+		return echo.NewHTTPError(501, "cache admin is not configured")
+	}
+	f, err := h.parseCacheFilter(c)
+	if err != nil {
+		return err
+	}
+	if f.empty() && !f.all {
+		return echo.NewHTTPError(400, "domain or specs is required (or all=true)")
+	}
+	entries, err := h.filteredCache(c, f)
+	if err != nil {
+		return err
+	}
+	deleted := 0
+	for _, entry := range entries {
+		err := h.admin.Cache.DeleteCache(c.Request().Context(), entry.Key)
+		if errors.Is(err, adapters.ErrKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return echo.NewHTTPError(500, fmt.Errorf("delete cache entry %s: %w", entry.Key, err))
+		}
+		deleted++
+	}
+	log.Infof("admin purged cache domain=%s spec_key=%s deleted=%d", f.domain, f.specKey, deleted)
+	return c.JSON(200, purgeResponse{Deleted: deleted})
+}
+
+type refreshRequest struct {
+	Specs string `json:"specs"`
+}
+
+type refreshResponse struct {
+	Key   string `json:"key"`
+	Items int    `json:"items"`
+}
+
+// handleRefreshCache renders spec bypassing cache and waits for result.
+// Task has no request headers, so variants cached with cookies are not refreshed.
+func (h *Handler) handleRefreshCache(c echo.Context) error {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxSpecsBodySize))
+	if err != nil {
+		return echo.NewHTTPError(400, fmt.Errorf("read body: %w", err))
+	}
+	var req refreshRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return echo.NewHTTPError(400, fmt.Errorf("unmarshal request: %w", err))
+	}
+	specs, err := h.decodeSpecs(req.Specs)
+	if err != nil {
+		return echo.NewHTTPError(400, fmt.Errorf("decode specs: %w", err))
+	}
+	task, err := taskFromSpecs(specs)
+	if err != nil {
+		return echo.NewHTTPError(400, err.Error())
+	}
+	encodedTask, err := json.Marshal(task)
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("task marshal error: %v", err))
+	}
+
+	timeoutCtx, cancel := taskContext(c.Request().Context())
+	defer cancel()
+	taskResultBytes, err := h.workQueue.Enqueue(timeoutCtx, task.CacheKey(), encodedTask)
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("task enqueue failed: %v", err))
+	}
+	if err := taskError(taskResultBytes); err != nil {
+		return err
+	}
+	var result models.TaskResult
+	if err := json.Unmarshal(taskResultBytes, &result); err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("result unmarshal failed: %v", err))
+	}
+	log.Infof("admin refreshed cache key=%s", task.CacheKey())
+	return c.JSON(200, refreshResponse{Key: task.CacheKey(), Items: len(result.Items)})
+}
+
+const (
+	taskStatePending  = "pending"
+	taskStateInFlight = "in_flight"
+	taskStateDead     = "dead"
+)
+
+// queuedTaskResponse shows task without headers, they may contain cookies
+type queuedTaskResponse struct {
+	Seq       uint64          `json:"seq"`
+	Key       string          `json:"key"`
+	State     string          `json:"state"`
+	Published time.Time       `json:"published"`
+	TaskType  models.TaskType `json:"task_type,omitempty"`
+	URL       string          `json:"url,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+func queuedTasksResponse(messages []adapters.QueuedMessage, dead bool) []queuedTaskResponse {
+	result := make([]queuedTaskResponse, 0, len(messages))
+	for _, msg := range messages {
+		resp := queuedTaskResponse{
+			Seq:       msg.Seq,
+			Key:       msg.Key,
+			State:     taskStatePending,
+			Published: msg.Published,
+			Error:     msg.Error,
+		}
+		switch {
+		case dead:
+			resp.State = taskStateDead
+		case msg.InFlight:
+			resp.State = taskStateInFlight
+		}
+		var task models.Task
+		if err := json.Unmarshal(msg.Payload, &task); err == nil {
+			resp.TaskType = task.TaskType
+			resp.URL = task.URL
+		}
+		result = append(result, resp)
+	}
+	return result
+}
+
+func queryLimit(c echo.Context) (int, error) {
+	param := c.QueryParam("limit")
+	if param == "" {
+		return defaultAdminListLimit, nil
+	}
+	limit, err := strconv.Atoi(param)
+	if err != nil || limit <= 0 || limit > maxAdminListLimit {
+		return 0, echo.NewHTTPError(400, fmt.Sprintf("limit must be in 1..%d", maxAdminListLimit))
+	}
+	return limit, nil
+}
+
+func (h *Handler) handleListTasks(c echo.Context) error {
+	if h.admin.Queue == nil {
+		return echo.NewHTTPError(501, "queue admin is not configured")
+	}
+	limit, err := queryLimit(c)
+	if err != nil {
+		return err
+	}
+	messages, err := h.admin.Queue.ListTasks(c.Request().Context(), limit)
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("list tasks: %w", err))
+	}
+	return c.JSON(200, queuedTasksResponse(messages, false))
+}
+
+func (h *Handler) handleListDeadLetters(c echo.Context) error {
+	if h.admin.Queue == nil {
+		return echo.NewHTTPError(501, "queue admin is not configured")
+	}
+	limit, err := queryLimit(c)
+	if err != nil {
+		return err
+	}
+	messages, err := h.admin.Queue.ListDeadLetters(c.Request().Context(), limit)
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("list dead letters: %w", err))
+	}
+	return c.JSON(200, queuedTasksResponse(messages, true))
+}
+
+func (h *Handler) handleRetryDeadLetter(c echo.Context) error {
+	if h.admin.Queue == nil {
+		return echo.NewHTTPError(501, "queue admin is not configured")
+	}
+	seq, err := strconv.ParseUint(c.Param("seq"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(400, "invalid seq")
+	}
+	err = h.admin.Queue.RetryDeadLetter(c.Request().Context(), seq)
+	if errors.Is(err, adapters.ErrKeyNotFound) {
+		return echo.NewHTTPError(404, "dead letter not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("retry dead letter: %w", err))
+	}
+	log.Infof("admin retried dead letter seq=%d", seq)
+	return c.NoContent(202)
+}
+
+func (h *Handler) handlePurgeDeadLetters(c echo.Context) error {
+	if h.admin.Queue == nil {
+		return echo.NewHTTPError(501, "queue admin is not configured")
+	}
+	if err := h.admin.Queue.PurgeDeadLetters(c.Request().Context()); err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("purge dead letters: %w", err))
+	}
+	log.Infof("admin purged dead letters")
+	return c.NoContent(204)
+}
+
+func (h *Handler) handleLimiterStates(c echo.Context) error {
+	if h.admin.Limiter == nil {
+		return echo.NewHTTPError(501, "limiter admin is not configured")
+	}
+	states, err := h.admin.Limiter.States(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("limiter states: %w", err))
+	}
+	if states == nil {
+		states = []limiter.State{}
+	}
+	return c.JSON(200, states)
+}
+
+func (h *Handler) handleResetLimiter(c echo.Context) error {
+	if h.admin.Limiter == nil {
+		return echo.NewHTTPError(501, "limiter admin is not configured")
+	}
+	key := c.Param("key")
+	if err := h.admin.Limiter.Reset(c.Request().Context(), key); err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("reset limiter: %w", err))
+	}
+	log.Infof("admin reset limiter key=%s", key)
+	return c.NoContent(204)
+}
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/egor3f/rssalchemy/internal/api/http/pb"
+	"github.com/egor3f/rssalchemy/internal/limiter"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/specs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type memCacheAdmin struct {
+	entries map[string]models.CacheEntry
+}
+
+func (m *memCacheAdmin) ListCache(context.Context) ([]models.CacheEntry, error) {
+	var result []models.CacheEntry
+	for _, entry := range m.entries {
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+func (m *memCacheAdmin) GetCacheEntry(_ context.Context, key string) (models.CacheEntry, []byte, error) {
+	entry, ok := m.entries[key]
+	if !ok {
+		return models.CacheEntry{}, nil, adapters.ErrKeyNotFound
+	}
+	return entry, []byte(`{"Title":"cached"}`), nil
+}
+
+func (m *memCacheAdmin) DeleteCache(_ context.Context, key string) error {
+	if _, ok := m.entries[key]; !ok {
+		return adapters.ErrKeyNotFound
+	}
+	delete(m.entries, key)
+	return nil
+}
+
+type memQueueAdmin struct {
+	tasks   []adapters.QueuedMessage
+	dead    []adapters.QueuedMessage
+	retried []uint64
+}
+
+func (m *memQueueAdmin) ListTasks(context.Context, int) ([]adapters.QueuedMessage, error) {
+	return m.tasks, nil
+}
+
+func (m *memQueueAdmin) ListDeadLetters(context.Context, int) ([]adapters.QueuedMessage, error) {
+	return m.dead, nil
+}
+
+func (m *memQueueAdmin) RetryDeadLetter(_ context.Context, seq uint64) error {
+	for i, msg := range m.dead {
+		if msg.Seq == seq {
+			m.dead = append(m.dead[:i], m.dead[i+1:]...)
+			m.retried = append(m.retried, seq)
+			return nil
+		}
+	}
+	return adapters.ErrKeyNotFound
+}
+
+func (m *memQueueAdmin) PurgeDeadLetters(context.Context) error {
+	m.dead = nil
+	return nil
+}
+
+type memLimiter struct {
+	states map[string]limiter.State
+}
+
+func (m *memLimiter) States(context.Context) ([]limiter.State, error) {
+	var result []limiter.State
+	for _, state := range m.states {
+		result = append(result, state)
+	}
+	return result, nil
+}
+
+func (m *memLimiter) Reset(_ context.Context, key string) error {
+	delete(m.states, key)
+	return nil
+}
+
+func encodeTestSpecs(t *testing.T, s *pb.Specs) string {
+	t.Helper()
+	encoded, err := specs.Encode(s)
+	require.NoError(t, err)
+	return encoded
+}
+
+var adminHeaders = map[string]string{"X-Api-Key": "admin-secret-0123456789"}
+
+// adminAuth has admin key of adminHeaders
+var adminAuth = AuthConfig{AdminKey: "admin-secret-0123456789"}
+
+func TestAdminCache(t *testing.T) {
+	specs := &pb.Specs{
+		Url:               "https://news.example.com/",
+		SelectorPost:      ".post",
+		SelectorTitle:     ".title",
+		SelectorLink:      "a",
+		SelectorCreated:   ".date",
+		SelectorEnclosure: "img",
+		CacheLifetime:     "10m",
+	}
+	encoded := encodeTestSpecs(t, specs)
+	task, err := taskFromSpecs(specs)
+	require.NoError(t, err)
+
+	cache := &memCacheAdmin{entries: map[string]models.CacheEntry{
+		"a": {Key: "a", SpecKey: task.SpecKey(), Domain: "news.example.com", Created: time.Now()},
+		"b": {Key: "b", SpecKey: "other", Domain: "example.com", Created: time.Now().Add(-time.Hour)},
+		"c": {Key: "c", SpecKey: "other", Domain: "example.org"},
+	}}
+	e := newTestServer(t, Config{Auth: adminAuth, Admin: AdminConfig{Cache: cache}})
+
+	listKeys := func(query string) []string {
+		rec := doRequest(e, http.MethodGet, "/api/v1/admin/cache"+query, "", adminHeaders)
+		require.Equal(t, 200, rec.Code, rec.Body.String())
+		var entries []models.CacheEntry
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entries))
+		var keys []string
+		for _, entry := range entries {
+			keys = append(keys, entry.Key)
+		}
+		return keys
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, listKeys(""))
+	assert.Equal(t, []string{"a", "b"}, listKeys("?domain=example.com"))
+	assert.Equal(t, []string{"a"}, listKeys("?specs="+encoded))
+
+	rec := doRequest(e, http.MethodGet, "/api/v1/admin/cache/a", "", adminHeaders)
+	assert.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"result":{"Title":"cached"}`)
+	rec = doRequest(e, http.MethodGet, "/api/v1/admin/cache/missing", "", adminHeaders)
+	assert.Equal(t, 404, rec.Code)
+
+	rec = doRequest(e, http.MethodDelete, "/api/v1/admin/cache", "", adminHeaders)
+	assert.Equal(t, 400, rec.Code, "purge without filter")
+	rec = doRequest(e, http.MethodDelete, "/api/v1/admin/cache?domain=example.com", "", adminHeaders)
+	assert.Equal(t, 200, rec.Code)
+	assert.JSONEq(t, `{"deleted":2}`, rec.Body.String())
+	assert.Equal(t, []string{"c"}, listKeys(""))
+
+	rec = doRequest(e, http.MethodDelete, "/api/v1/admin/cache/c", "", adminHeaders)
+	assert.Equal(t, 204, rec.Code)
+	assert.Empty(t, cache.entries)
+}
+
+func TestAdminQueue(t *testing.T) {
+	payload, err := json.Marshal(models.Task{
+		TaskType: models.TaskTypeExtract,
+		URL:      "https://example.com/",
+		Headers:  map[string]string{"Cookie": "session=secret"},
+	})
+	require.NoError(t, err)
+	queue := &memQueueAdmin{
+		tasks: []adapters.QueuedMessage{
+			{Seq: 1, Key: "k1", InFlight: true, Payload: payload},
+			{Seq: 2, Key: "k2", Payload: payload},
+		},
+		dead: []adapters.QueuedMessage{
+			{Seq: 7, Key: "k3", Error: "no posts on page", Payload: payload},
+		},
+	}
+	e := newTestServer(t, Config{Auth: adminAuth, Admin: AdminConfig{Queue: queue}})
+
+	rec := doRequest(e, http.MethodGet, "/api/v1/admin/tasks", "", adminHeaders)
+	require.Equal(t, 200, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "secret", "headers must not be shown")
+	var tasks []queuedTaskResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tasks))
+	require.Len(t, tasks, 2)
+	assert.Equal(t, taskStateInFlight, tasks[0].State)
+	assert.Equal(t, taskStatePending, tasks[1].State)
+	assert.Equal(t, "https://example.com/", tasks[1].URL)
+
+	rec = doRequest(e, http.MethodGet, "/api/v1/admin/tasks?limit=0", "", adminHeaders)
+	assert.Equal(t, 400, rec.Code)
+
+	rec = doRequest(e, http.MethodGet, "/api/v1/admin/dlq", "", adminHeaders)
+	require.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"error":"no posts on page"`)
+	assert.Contains(t, rec.Body.String(), `"state":"dead"`)
+
+	rec = doRequest(e, http.MethodPost, "/api/v1/admin/dlq/8/retry", "", adminHeaders)
+	assert.Equal(t, 404, rec.Code)
+	rec = doRequest(e, http.MethodPost, "/api/v1/admin/dlq/7/retry", "", adminHeaders)
+	assert.Equal(t, 202, rec.Code)
+	assert.Equal(t, []uint64{7}, queue.retried)
+}
+
+func TestAdminLimiter(t *testing.T) {
+	lim := &memLimiter{states: map[string]limiter.State{
+		"example.com": {Key: "example.com", Queued: 3},
+	}}
+	e := newTestServer(t, Config{Auth: adminAuth, Admin: AdminConfig{Limiter: lim}})
+
+	rec := doRequest(e, http.MethodGet, "/api/v1/admin/limiter", "", adminHeaders)
+	require.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"key":"example.com","queued":3`)
+
+	rec = doRequest(e, http.MethodDelete, "/api/v1/admin/limiter/example.com", "", adminHeaders)
+	assert.Equal(t, 204, rec.Code)
+	assert.Empty(t, lim.states)
+}
+
+func TestAdminAccess(t *testing.T) {
+	e := newTestServer(t, Config{Auth: adminAuth})
+	rec := doRequest(e, http.MethodGet, "/api/v1/admin/cache", "", nil)
+	assert.Equal(t, 401, rec.Code)
+	for _, target := range []string{"/api/v1/admin/cache", "/api/v1/admin/tasks", "/api/v1/admin/limiter"} {
+		rec := doRequest(e, http.MethodGet, target, "", adminHeaders)
+		assert.Equal(t, 501, rec.Code, target)
+	}
+}
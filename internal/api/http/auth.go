@@ -0,0 +1,292 @@
+package http
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"golang.org/x/time/rate"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	apiKeyHeader      = "X-Api-Key"
+	apiKeyQueryParam  = "key"
+	apiKeyContextKey  = "api_key"
+	adminApiKeyID     = "admin"
+	apiKeySecretSize  = 24
+	maxApiKeyBodySize = 4 * 1024
+)
+
+// AuthConfig configures optional api key authentication.
+// If Required is false, requests without key are rate limited by ip with handler defaults.
+type AuthConfig struct {
+	Required bool
+	// StaticKeys in format name:secret
+	StaticKeys []string
+	AdminKey   string
+	// Store is optional, without it keys can't be managed via api and daily quotas are not enforced
+	Store             adapters.ApiKeyStore
+	DefaultRateLimit  rate.Limit
+	DefaultBurst      int
+	DefaultDailyQuota int
+}
+
+type apiKeyIdentity struct {
+	id  string
+	key models.ApiKey
+}
+
+type apiKeyResponse struct {
+	ID string `json:"id"`
+	models.ApiKey
+	Static bool `json:"static,omitempty"`
+	// Secret is returned only once, when key is created
+	Secret string `json:"secret,omitempty"`
+}
+
+// ApiKeyID returns id of api key. Secrets are never stored, so leaked store doesn't leak keys.
+func ApiKeyID(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func parseStaticKeys(entries []string) (map[string]models.ApiKey, error) {
+	keys := make(map[string]models.ApiKey, len(entries))
+	for _, entry := range entries {
+		name, secret, found := strings.Cut(entry, ":")
+		if !found || name == "" || secret == "" {
+			return nil, fmt.Errorf("invalid api key entry, expected name:key")
+		}
+		keys[ApiKeyID(secret)] = models.ApiKey{Name: name}
+	}
+	return keys, nil
+}
+
+// apiKeySecret returns key from X-Api-Key header, bearer authorization or key query param.
+// Query param is for rss readers which can't send headers, fromQuery reports it was used.
+func apiKeySecret(c echo.Context) (secret string, fromQuery bool) {
+	if secret := c.Request().Header.Get(apiKeyHeader); secret != "" {
+		return secret, false
+	}
+	if auth := c.Request().Header.Get(echo.HeaderAuthorization); auth != "" {
+		scheme, token, found := strings.Cut(auth, " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token), false
+		}
+	}
+	return c.QueryParam(apiKeyQueryParam), true
+}
+
+func (h *Handler) lookupApiKey(secret string) (apiKeyIdentity, error) {
+	if h.auth.AdminKey != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(h.auth.AdminKey)) == 1 {
+		return apiKeyIdentity{id: adminApiKeyID, key: models.ApiKey{Name: adminApiKeyID, Admin: true, DailyQuota: -1}}, nil
+	}
+	id := ApiKeyID(secret)
+	if key, ok := h.staticKeys[id]; ok {
+		return apiKeyIdentity{id: id, key: key}, nil
+	}
+	if h.auth.Store == nil {
+		return apiKeyIdentity{}, adapters.ErrKeyNotFound
+	}
+	key, err := h.auth.Store.GetApiKey(id)
+	if err != nil {
+		return apiKeyIdentity{}, err
+	}
+	return apiKeyIdentity{id: id, key: key}, nil
+}
+
+// authenticate puts api key of request into context. Invalid key is rejected even if auth is not required,
+// so client notices misconfiguration instead of silently getting anonymous limits.
+func (h *Handler) authenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		secret, fromQuery := apiKeySecret(c)
+		if secret == "" {
+			if h.auth.Required {
+				return echo.NewHTTPError(401, "api key required")
+			}
+			return next(c)
+		}
+		ident, err := h.lookupApiKey(secret)
+		if errors.Is(err, adapters.ErrKeyNotFound) {
+			return echo.NewHTTPError(401, "invalid api key")
+		}
+		if err != nil {
+			return echo.NewHTTPError(500, fmt.Errorf("api key lookup: %w", err))
+		}
+		// urls end up in browser history, proxy logs and shared links
+		if fromQuery && ident.key.Admin {
+			return echo.NewHTTPError(401, "admin api key must be passed in header")
+		}
+		c.Set(apiKeyContextKey, ident)
+		return next(c)
+	}
+}
+
+func (h *Handler) requireAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ident, ok := c.Get(apiKeyContextKey).(apiKeyIdentity)
+		if !ok {
+			return echo.NewHTTPError(401, "api key required")
+		}
+		if !ident.key.Admin {
+			return echo.NewHTTPError(403, "admin api key required")
+		}
+		return next(c)
+	}
+}
+
+// checkRateLimit applies rate limit and daily quota of api key, or rate limit by ip for anonymous requests
+func (h *Handler) checkRateLimit(c echo.Context) error {
+	limiterKey := "ip:" + c.RealIP()
+	limit, burst := h.rateLimit, h.rateLimitBurst
+	ident, hasKey := c.Get(apiKeyContextKey).(apiKeyIdentity)
+	if hasKey {
+		limiterKey = "key:" + ident.id
+		limit, burst = h.auth.DefaultRateLimit, h.auth.DefaultBurst
+		if ident.key.RateLimitEvery > 0 {
+			limit = rate.Every(time.Duration(float64(time.Second) * ident.key.RateLimitEvery))
+		}
+		if ident.key.RateLimitBurst > 0 {
+			burst = ident.key.RateLimitBurst
+		}
+	}
+
+	limiter := h.limiter(limiterKey, limit, burst)
+	log.Debugf("Rate limiter for %s tokens=%f", limiterKey, limiter.Tokens())
+	if !limiter.Allow() {
+		return echo.ErrTooManyRequests
+	}
+	if hasKey {
+		return h.checkQuota(ident)
+	}
+	return nil
+}
+
+// limiter returns limiter for key, limits of existing limiter are updated because key settings could change
+func (h *Handler) limiter(key string, limit rate.Limit, burst int) *rate.Limiter {
+	h.limitsMu.RLock()
+	limiter, ok := h.limits[key]
+	h.limitsMu.RUnlock()
+	if !ok {
+		h.limitsMu.Lock()
+		limiter, ok = h.limits[key]
+		if !ok {
+			limiter = rate.NewLimiter(limit, burst)
+			h.limits[key] = limiter
+		}
+		h.limitsMu.Unlock()
+	}
+	if limiter.Limit() != limit {
+		limiter.SetLimit(limit)
+	}
+	if limiter.Burst() != burst {
+		limiter.SetBurst(burst)
+	}
+	return limiter
+}
+
+func (h *Handler) checkQuota(ident apiKeyIdentity) error {
+	quota := ident.key.DailyQuota
+	if quota == 0 {
+		quota = h.auth.DefaultDailyQuota
+	}
+	if quota <= 0 {
+		return nil
+	}
+	if h.auth.Store == nil {
+		log.Warnf("api key %s has quota, but there is no store to count usage", ident.key.Name)
+		return nil
+	}
+	used, err := h.auth.Store.IncrUsage(ident.id, time.Now().UTC().Format("2006-01-02"))
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("count api key usage: %w", err))
+	}
+	if used > int64(quota) {
+		return echo.NewHTTPError(429, "daily quota exceeded")
+	}
+	return nil
+}
+
+func (h *Handler) handleListApiKeys(c echo.Context) error {
+	var result []apiKeyResponse
+	for id, key := range h.staticKeys {
+		result = append(result, apiKeyResponse{ID: id, ApiKey: key, Static: true})
+	}
+	if h.auth.Store != nil {
+		keys, err := h.auth.Store.ListApiKeys()
+		if err != nil {
+			return echo.NewHTTPError(500, fmt.Errorf("list api keys: %w", err))
+		}
+		for id, key := range keys {
+			result = append(result, apiKeyResponse{ID: id, ApiKey: key})
+		}
+	}
+	return c.JSON(200, result)
+}
+
+// handleCreateApiKey creates key with random secret. Secret is returned only in this response.
+func (h *Handler) handleCreateApiKey(c echo.Context) error {
+	if h.auth.Store == nil {
+		return echo.NewHTTPError(501, "api key store is not configured")
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxApiKeyBodySize))
+	if err != nil {
+		return echo.NewHTTPError(400, fmt.Errorf("read body: %w", err))
+	}
+	var key models.ApiKey
+	if err := json.Unmarshal(body, &key); err != nil {
+		return echo.NewHTTPError(400, fmt.Errorf("unmarshal api key: %w", err))
+	}
+	if key.Name == "" {
+		return echo.NewHTTPError(400, "name is required")
+	}
+	if key.RateLimitEvery < 0 || key.RateLimitBurst < 0 {
+		return echo.NewHTTPError(400, "rate limit must not be negative")
+	}
+	key.Created = time.Now().UTC()
+
+	secretBytes := make([]byte, apiKeySecretSize)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("generate secret: %w", err))
+	}
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+	id := ApiKeyID(secret)
+	if err := h.auth.Store.PutApiKey(id, key); err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("put api key: %w", err))
+	}
+	log.Infof("created api key name=%s id=%s admin=%v", key.Name, id, key.Admin)
+	return c.JSON(201, apiKeyResponse{ID: id, ApiKey: key, Secret: secret})
+}
+
+func (h *Handler) handleDeleteApiKey(c echo.Context) error {
+	id := c.Param("id")
+	if _, ok := h.staticKeys[id]; ok {
+		return echo.NewHTTPError(400, "static api keys can be removed only from config")
+	}
+	if h.auth.Store == nil {
+		return echo.NewHTTPError(404, "api key not found")
+	}
+	err := h.auth.Store.DeleteApiKey(id)
+	if errors.Is(err, adapters.ErrKeyNotFound) {
+		return echo.NewHTTPError(404, "api key not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("delete api key: %w", err))
+	}
+	h.limitsMu.Lock()
+	delete(h.limits, "key:"+id)
+	h.limitsMu.Unlock()
+	log.Infof("deleted api key id=%s", id)
+	return c.NoContent(204)
+}
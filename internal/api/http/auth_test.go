@@ -0,0 +1,186 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memKeyStore struct {
+	mu    sync.Mutex
+	keys  map[string]models.ApiKey
+	usage map[string]int64
+}
+
+func newMemKeyStore() *memKeyStore {
+	return &memKeyStore{keys: make(map[string]models.ApiKey), usage: make(map[string]int64)}
+}
+
+func (s *memKeyStore) GetApiKey(id string) (models.ApiKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.keys[id]
+	if !ok {
+		return models.ApiKey{}, adapters.ErrKeyNotFound
+	}
+	return key, nil
+}
+
+func (s *memKeyStore) PutApiKey(id string, key models.ApiKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[id] = key
+	return nil
+}
+
+func (s *memKeyStore) DeleteApiKey(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[id]; !ok {
+		return adapters.ErrKeyNotFound
+	}
+	delete(s.keys, id)
+	return nil
+}
+
+func (s *memKeyStore) ListApiKeys() (map[string]models.ApiKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make(map[string]models.ApiKey, len(s.keys))
+	for id, key := range s.keys {
+		result[id] = key
+	}
+	return result, nil
+}
+
+func (s *memKeyStore) IncrUsage(id string, period string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usage[id+"."+period]++
+	return s.usage[id+"."+period], nil
+}
+
+type nopQueue struct{}
+
+func (nopQueue) Enqueue(context.Context, string, []byte) ([]byte, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (nopQueue) Get(string) ([]byte, time.Time, error) {
+	return nil, time.Time{}, adapters.ErrKeyNotFound
+}
+
+func (nopQueue) Set(string, []byte) error {
+	return nil
+}
+
+func doRequest(e *echo.Echo, method string, target string, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthenticate(t *testing.T) {
+	store := newMemKeyStore()
+	require.NoError(t, store.PutApiKey(ApiKeyID("stored-secret"), models.ApiKey{Name: "stored"}))
+
+	tests := []struct {
+		name     string
+		required bool
+		target   string
+		headers  map[string]string
+		status   int
+	}{
+		{"anonymous allowed", false, "/api/v1/limited", nil, 200},
+		{"anonymous rejected", true, "/api/v1/limited", nil, 401},
+		{"static key header", true, "/api/v1/limited", map[string]string{"X-Api-Key": "static-secret"}, 200},
+		{"bearer token", true, "/api/v1/limited", map[string]string{"Authorization": "Bearer stored-secret"}, 200},
+		{"query param", true, "/api/v1/limited?key=stored-secret", nil, 200},
+		{"invalid key", false, "/api/v1/limited?key=wrong", nil, 401},
+		{"admin endpoint without key", false, "/api/v1/admin/keys", nil, 401},
+		{"admin endpoint with user key", false, "/api/v1/admin/keys?key=stored-secret", nil, 403},
+		{"admin endpoint with admin key", false, "/api/v1/admin/keys", map[string]string{"X-Api-Key": "admin-secret"}, 200},
+		{"admin key in query param", false, "/api/v1/admin/keys?key=admin-secret", nil, 401},
+		{"admin key in query param on user endpoint", false, "/api/v1/limited?key=admin-secret", nil, 401},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestServer(t, Config{Auth: AuthConfig{
+				Required:   tt.required,
+				StaticKeys: []string{"static:static-secret"},
+				AdminKey:   "admin-secret",
+				Store:      store,
+			}})
+			rec := doRequest(e, http.MethodGet, tt.target, "", tt.headers)
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestApiKeyLimits(t *testing.T) {
+	store := newMemKeyStore()
+	require.NoError(t, store.PutApiKey(ApiKeyID("quota"), models.ApiKey{Name: "quota", DailyQuota: 2}))
+	require.NoError(t, store.PutApiKey(ApiKeyID("burst"), models.ApiKey{Name: "burst", RateLimitEvery: 3600, RateLimitBurst: 1}))
+	require.NoError(t, store.PutApiKey(ApiKeyID("unlimited"), models.ApiKey{Name: "unlimited", DailyQuota: -1}))
+
+	e := newTestServer(t, Config{Auth: AuthConfig{
+		Store:             store,
+		DefaultDailyQuota: 1,
+	}})
+	statuses := func(key string, n int) []int {
+		var result []int
+		for range n {
+			result = append(result, doRequest(e, http.MethodGet, "/api/v1/limited?key="+key, "", nil).Code)
+		}
+		return result
+	}
+	assert.Equal(t, []int{200, 200, 429}, statuses("quota", 3))
+	assert.Equal(t, []int{200, 429}, statuses("burst", 2))
+	assert.Equal(t, []int{200, 200, 200}, statuses("unlimited", 3))
+}
+
+func TestApiKeyManagement(t *testing.T) {
+	store := newMemKeyStore()
+	e := newTestServer(t, Config{Auth: AuthConfig{
+		AdminKey: "admin-secret",
+		Store:    store,
+	}})
+	admin := map[string]string{"X-Api-Key": "admin-secret"}
+
+	rec := doRequest(e, http.MethodPost, "/api/v1/admin/keys", `{"name": "reader", "daily_quota": 10}`, admin)
+	require.Equal(t, 201, rec.Code, rec.Body.String())
+	var created apiKeyResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.Secret)
+	id := created.ID
+	assert.Equal(t, ApiKeyID(created.Secret), id)
+	assert.Equal(t, 10, store.keys[id].DailyQuota)
+
+	rec = doRequest(e, http.MethodGet, "/api/v1/limited", "", map[string]string{"X-Api-Key": created.Secret})
+	assert.Equal(t, 200, rec.Code)
+
+	rec = doRequest(e, http.MethodPost, "/api/v1/admin/keys", `{}`, admin)
+	assert.Equal(t, 400, rec.Code)
+
+	rec = doRequest(e, http.MethodDelete, "/api/v1/admin/keys/"+id, "", admin)
+	assert.Equal(t, 204, rec.Code)
+	assert.Empty(t, store.keys)
+
+	rec = doRequest(e, http.MethodDelete, "/api/v1/admin/keys/"+id, "", admin)
+	assert.Equal(t, 404, rec.Code)
+}
@@ -1,103 +1,148 @@
 package http
 
 import (
-	"bytes"
-	"compress/flate"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/egor3f/rssalchemy/internal/adapters"
 	"github.com/egor3f/rssalchemy/internal/api/http/pb"
+	"github.com/egor3f/rssalchemy/internal/feed"
 	"github.com/egor3f/rssalchemy/internal/models"
-	"github.com/egor3f/rssalchemy/internal/validators"
-	"github.com/go-playground/validator/v10"
-	"github.com/gorilla/feeds"
+	"github.com/egor3f/rssalchemy/internal/presets"
+	"github.com/egor3f/rssalchemy/internal/signing"
+	"github.com/egor3f/rssalchemy/internal/specs"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"golang.org/x/time/rate"
-	"google.golang.org/protobuf/proto"
-	"html"
 	"io"
 	"net/url"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 )
 
 const (
-	taskTimeout = 1 * time.Minute
-	minLifetime = time.Duration(0)
-	maxLifetime = 24 * time.Hour
+	taskTimeout      = 1 * time.Minute
+	maxSpecsBodySize = 64 * 1024
+	minLifetime      = time.Duration(0)
+	maxLifetime      = 24 * time.Hour
 )
 
 type Handler struct {
-	validate       *validator.Validate
 	workQueue      adapters.WorkQueue
 	cache          adapters.Cache
 	rateLimit      rate.Limit
 	rateLimitBurst int
-	limits         map[string]*rate.Limiter
-	limitsMu       sync.RWMutex
-	debug          bool
+	// limits are keyed by api key id or by ip for anonymous requests
+	limits     map[string]*rate.Limiter
+	limitsMu   sync.RWMutex
+	auth       AuthConfig
+	staticKeys map[string]models.ApiKey
+	admin      AdminConfig
+	// signer is nil if signing is disabled
+	signer *signing.Signer
+	// links is nil if short links are disabled
+	links   adapters.LinkStore
+	presets *presets.Registry
+	debug   bool
 }
 
-func New(wq adapters.WorkQueue, cache adapters.Cache, rateLimit rate.Limit, rateLimitBurst int, debug bool) *Handler {
-	if wq == nil || cache == nil {
+// Config is dependencies and settings of Handler, WorkQueue, Cache and Presets are required
+type Config struct {
+	WorkQueue adapters.WorkQueue
+	Cache     adapters.Cache
+	// RateLimit and RateLimitBurst limit tasks of anonymous clients by ip
+	RateLimit      rate.Limit
+	RateLimitBurst int
+	Auth           AuthConfig
+	Admin          AdminConfig
+	// Signer is nil if signing is disabled
+	Signer *signing.Signer
+	// Links is nil if short links are disabled
+	Links   adapters.LinkStore
+	Presets *presets.Registry
+	Debug   bool
+}
+
+func New(cfg Config) *Handler {
+	if cfg.WorkQueue == nil || cfg.Cache == nil || cfg.Presets == nil {
 		panic("you fckd up with di again")
 	}
+	staticKeys, err := parseStaticKeys(cfg.Auth.StaticKeys)
+	if err != nil {
+		log.Panicf("parse static api keys: %v", err)
+	}
 	h := Handler{
-		workQueue:      wq,
-		cache:          cache,
-		rateLimit:      rateLimit,
-		rateLimitBurst: rateLimitBurst,
+		workQueue:      cfg.WorkQueue,
+		cache:          cfg.Cache,
+		rateLimit:      cfg.RateLimit,
+		rateLimitBurst: cfg.RateLimitBurst,
 		limits:         make(map[string]*rate.Limiter),
-		debug:          debug,
-	}
-	h.validate = validator.New(validator.WithRequiredStructEnabled())
-	if err := h.validate.RegisterValidation("selector", validators.ValidateSelector); err != nil {
-		log.Panicf("register validation: %v", err)
+		auth:           cfg.Auth,
+		staticKeys:     staticKeys,
+		admin:          cfg.Admin,
+		signer:         cfg.Signer,
+		links:          cfg.Links,
+		presets:        cfg.Presets,
+		debug:          cfg.Debug,
 	}
 	return &h
 }
 
 func (h *Handler) SetupRoutes(g *echo.Group) {
+	g.Use(h.authenticate)
 	g.GET("/render/:specs", h.handleRender)
 	g.GET("/screenshot", h.handlePageScreenshot)
+	g.GET("/snapshot", h.handlePageSnapshot)
+	g.POST("/preview", h.handlePreview)
+	g.POST("/sign", h.handleSign)
+	g.POST("/links", h.handleCreateLink)
+	g.PUT("/links/:id", h.handleUpdateLink)
+	g.GET("/feed/:id", h.handleFeed)
+	g.GET("/presets", h.handleListPresets)
+	g.GET("/presets/:name", h.handleGetPreset)
+	g.POST("/opml/export", h.handleExportOPML)
+	g.POST("/opml/import", h.handleImportOPML)
+
+	h.setupAdminRoutes(g.Group("/admin", h.requireAdmin))
 }
 
 func (h *Handler) handleRender(c echo.Context) error {
 	specsParam := c.Param("specs")
+	if err := h.checkSignature(c, specsParam); err != nil {
+		return err
+	}
 	specs, err := h.decodeSpecs(specsParam)
+	if errors.Is(err, presets.ErrNotFound) {
+		return echo.NewHTTPError(404, err.Error())
+	}
 	if err != nil {
 		return echo.NewHTTPError(400, fmt.Errorf("decode specs: %w", err))
 	}
+	return h.renderSpecs(c, specs)
+}
 
-	extractFrom, ok := map[pb.ExtractFrom]models.ExtractFrom{
-		pb.ExtractFrom_InnerText: models.ExtractFrom_InnerText,
-		pb.ExtractFrom_Attribute: models.ExtractFrom_Attribute,
-	}[specs.CreatedExtractFrom]
-	if !ok {
-		return echo.NewHTTPError(400, "invalid extract from")
-	}
+// renderSpecs responds with feed of specs, from cache or rendered by worker
+func (h *Handler) renderSpecs(c echo.Context, specs *pb.Specs) (errRet error) {
+	ctx, span := tracer.Start(c.Request().Context(), "renderSpecs")
+	defer func() {
+		if errRet != nil {
+			span.SetStatus(codes.Error, errRet.Error())
+		}
+		span.End()
+	}()
 
-	task := models.Task{
-		TaskType:             models.TaskTypeExtract,
-		URL:                  specs.Url,
-		SelectorPost:         specs.SelectorPost,
-		SelectorTitle:        specs.SelectorTitle,
-		SelectorLink:         specs.SelectorLink,
-		SelectorDescription:  specs.SelectorDescription,
-		SelectorAuthor:       specs.SelectorAuthor,
-		SelectorCreated:      specs.SelectorCreated,
-		CreatedExtractFrom:   extractFrom,
-		CreatedAttributeName: specs.CreatedAttributeName,
-		SelectorContent:      specs.SelectorContent,
-		SelectorEnclosure:    specs.SelectorEnclosure,
-		Headers:              extractHeaders(c),
+	task, err := taskFromSpecs(specs)
+	if err != nil {
+		return echo.NewHTTPError(400, err.Error())
 	}
+	task.Headers = extractHeaders(c)
+	span.SetAttributes(
+		attribute.String("url.full", task.URL),
+		attribute.String("rssalchemy.task_key", task.CacheKey()),
+	)
 
 	cacheLifetime, err := time.ParseDuration(specs.CacheLifetime)
 	if err != nil {
@@ -113,7 +158,7 @@ func (h *Handler) handleRender(c echo.Context) error {
 		cacheLifetime = 0
 	}
 
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), taskTimeout)
+	timeoutCtx, cancel := taskContext(ctx)
 	defer cancel()
 
 	encodedTask, err := json.Marshal(task)
@@ -126,9 +171,20 @@ func (h *Handler) handleRender(c echo.Context) error {
 	if err != nil && !errors.Is(err, adapters.ErrKeyNotFound) {
 		return echo.NewHTTPError(500, fmt.Errorf("cache failed: %v", err))
 	}
-	if errors.Is(err, adapters.ErrKeyNotFound) || time.Since(cachedTS) > cacheLifetime {
-		if !h.checkRateLimit(c) {
-			return echo.ErrTooManyRequests
+	cacheMiss := errors.Is(err, adapters.ErrKeyNotFound)
+	cacheStale := !cacheMiss && time.Since(cachedTS) > cacheLifetime
+	cacheResult := "hit"
+	switch {
+	case cacheMiss:
+		cacheResult = "miss"
+	case cacheStale:
+		cacheResult = "stale"
+	}
+	cacheRequests.WithLabelValues(cacheResult).Inc()
+	span.SetAttributes(attribute.String("rssalchemy.cache", cacheResult))
+	if cacheMiss || cacheStale {
+		if err := h.checkRateLimit(c); err != nil {
+			return err
 		}
 		taskResultBytes, err = h.workQueue.Enqueue(timeoutCtx, task.CacheKey(), encodedTask)
 		if err != nil {
@@ -136,12 +192,16 @@ func (h *Handler) handleRender(c echo.Context) error {
 		}
 	}
 
+	if err := taskError(taskResultBytes); err != nil {
+		return err
+	}
+
 	var result models.TaskResult
 	if err := json.Unmarshal(taskResultBytes, &result); err != nil {
 		return echo.NewHTTPError(500, fmt.Errorf("cached value unmarshal failed: %v", err))
 	}
 
-	atom, err := makeFeed(task, result)
+	atom, err := feed.Atom(task, result)
 	if err != nil {
 		log.Errorf("make feed failed: %v", err)
 		return echo.NewHTTPError(500)
@@ -151,19 +211,18 @@ func (h *Handler) handleRender(c echo.Context) error {
 	return c.String(200, atom)
 }
 
-func (h *Handler) handlePageScreenshot(c echo.Context) error {
-	pageUrl := c.QueryParam("url")
-	if _, err := url.Parse(pageUrl); err != nil {
-		return echo.NewHTTPError(400, "url is invalid or missing")
-	}
+// taskContext keeps request trace but not its cancellation: result is cached even if client has gone
+func taskContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), taskTimeout)
+}
 
-	task := models.Task{
-		TaskType: models.TaskTypePageScreenshot,
-		URL:      pageUrl,
-		Headers:  extractHeaders(c),
+func (h *Handler) handlePageScreenshot(c echo.Context) error {
+	task, err := h.pageTask(c, models.TaskTypePageScreenshot)
+	if err != nil {
+		return err
 	}
 
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), taskTimeout)
+	timeoutCtx, cancel := taskContext(c.Request().Context())
 	defer cancel()
 
 	encodedTask, err := json.Marshal(task)
@@ -171,8 +230,8 @@ func (h *Handler) handlePageScreenshot(c echo.Context) error {
 		return echo.NewHTTPError(500, fmt.Errorf("task marshal error: %v", err))
 	}
 
-	if !h.checkRateLimit(c) {
-		return echo.ErrTooManyRequests
+	if err := h.checkRateLimit(c); err != nil {
+		return err
 	}
 
 	taskResultBytes, err := h.workQueue.Enqueue(timeoutCtx, task.CacheKey(), encodedTask)
@@ -180,6 +239,10 @@ func (h *Handler) handlePageScreenshot(c echo.Context) error {
 		return echo.NewHTTPError(500, fmt.Errorf("queued cache failed: %v", err))
 	}
 
+	if err := taskError(taskResultBytes); err != nil {
+		return err
+	}
+
 	var result models.ScreenshotTaskResult
 	if err := json.Unmarshal(taskResultBytes, &result); err != nil {
 		return echo.NewHTTPError(500, fmt.Errorf("task result unmarshal failed: %v", err))
@@ -187,116 +250,150 @@ func (h *Handler) handlePageScreenshot(c echo.Context) error {
 	return c.Blob(200, "image/png", result.Image)
 }
 
-func (h *Handler) checkRateLimit(c echo.Context) bool {
-	h.limitsMu.RLock()
-	limiter, ok := h.limits[c.RealIP()]
-	h.limitsMu.RUnlock()
-	if !ok {
-		h.limitsMu.Lock()
-		limiter, ok = h.limits[c.RealIP()]
-		if !ok {
-			limiter = rate.NewLimiter(h.rateLimit, h.rateLimitBurst)
-			h.limits[c.RealIP()] = limiter
+// pageTask makes screenshot or snapshot task from url param or encoded specs.
+// Selectors from specs are used to highlight matched nodes.
+func (h *Handler) pageTask(c echo.Context, taskType models.TaskType) (models.Task, error) {
+	task := models.Task{
+		URL: c.QueryParam("url"),
+	}
+	if specsParam := c.QueryParam("specs"); specsParam != "" {
+		if err := h.checkSignature(c, specsParam); err != nil {
+			return models.Task{}, err
+		}
+		specs, err := h.decodeSpecs(specsParam)
+		if err != nil {
+			return models.Task{}, echo.NewHTTPError(400, fmt.Errorf("decode specs: %w", err))
 		}
-		h.limitsMu.Unlock()
+		if specs.SourceType == pb.SourceType_Html {
+			if task, err = taskFromSpecs(specs); err != nil {
+				return models.Task{}, echo.NewHTTPError(400, err.Error())
+			}
+		} else {
+			task.URL = specs.Url
+		}
+	} else if err := h.checkSignature(c, task.URL); err != nil {
+		return models.Task{}, err
+	}
+	if _, err := url.Parse(task.URL); err != nil || task.URL == "" {
+		return models.Task{}, echo.NewHTTPError(400, "url is invalid or missing")
 	}
-	log.Debugf("Rate limiter for ip=%s tokens=%f", c.RealIP(), limiter.Tokens())
-	return limiter.Allow()
+	task.TaskType = taskType
+	task.Headers = extractHeaders(c)
+	return task, nil
 }
 
-func (h *Handler) decodeSpecs(specsParam string) (*pb.Specs, error) {
-	var err error
-	version := 0
-	paramSplit := strings.Split(specsParam, ":")
-	if len(paramSplit) == 2 {
-		version, err = strconv.Atoi(paramSplit[0])
-		if err != nil {
-			return nil, fmt.Errorf("invalid version: %s", paramSplit[0])
-		}
-		specsParam = paramSplit[1]
+// handlePageSnapshot returns page html after rendering in browser.
+// If encoded specs are passed, nodes matched by selectors are highlighted.
+func (h *Handler) handlePageSnapshot(c echo.Context) error {
+	task, err := h.pageTask(c, models.TaskTypePageSnapshot)
+	if err != nil {
+		return err
 	}
 
-	decodedSpecsParam, err := base64.StdEncoding.WithPadding(base64.NoPadding).DecodeString(specsParam)
+	timeoutCtx, cancel := taskContext(c.Request().Context())
+	defer cancel()
+
+	encodedTask, err := json.Marshal(task)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode specs: %w", err)
+		return echo.NewHTTPError(500, fmt.Errorf("task marshal error: %v", err))
 	}
-	rc := flate.NewReader(bytes.NewReader(decodedSpecsParam))
-	decodedSpecsParam, err = io.ReadAll(rc)
+
+	if err := h.checkRateLimit(c); err != nil {
+		return err
+	}
+
+	taskResultBytes, err := h.workQueue.Enqueue(timeoutCtx, task.CacheKey(), encodedTask)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unzip specs: %w", err)
+		return echo.NewHTTPError(500, fmt.Errorf("task enqueue failed: %v", err))
 	}
 
-	specs := &pb.Specs{}
-	switch version {
-	case 0:
-		if err := json.Unmarshal(decodedSpecsParam, specs); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal json specs: %w", err)
-		}
-	case 1:
-		if err := proto.Unmarshal(decodedSpecsParam, specs); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal proto specs: %w", err)
-		}
-	default:
-		return nil, fmt.Errorf("unknown version: %d", version)
+	if err := taskError(taskResultBytes); err != nil {
+		return err
 	}
 
-	if err := h.validate.Struct(specs); err != nil {
-		return nil, fmt.Errorf("specs are invalid: %w", err)
+	var result models.SnapshotTaskResult
+	if err := json.Unmarshal(taskResultBytes, &result); err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("task result unmarshal failed: %v", err))
 	}
-	return specs, nil
+	// page scripts must not run on our origin
+	c.Response().Header().Set("Content-Security-Policy", "sandbox")
+	return c.HTML(200, result.HTML)
 }
 
-func makeFeed(task models.Task, result models.TaskResult) (string, error) {
-	feedTS := time.Now()
-	if len(result.Items) > 0 {
-		feedTS = result.Items[0].Created
+// handlePreview accepts specs as json (same format as v0 specs) and returns extraction diagnostics
+func (h *Handler) handlePreview(c echo.Context) error {
+	// preview fetches any url, so with signing enabled it's for key holders like signing itself
+	if _, hasKey := c.Get(apiKeyContextKey).(apiKeyIdentity); h.signer != nil && !hasKey {
+		return echo.NewHTTPError(401, "api key required")
 	}
-	feed := feeds.Feed{
-		Title:   html.EscapeString(result.Title),
-		Link:    &feeds.Link{Href: task.URL},
-		Updated: feedTS,
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxSpecsBodySize))
+	if err != nil {
+		return echo.NewHTTPError(400, fmt.Errorf("read body: %w", err))
 	}
-	for _, item := range result.Items {
-		itemUrl, err := url.Parse(item.Link)
-		if err != nil {
-			log.Errorf("Invalid item link, item=%+v", item)
-			continue
-		}
-		id := fmt.Sprintf(
-			"tag:%s,%s:%s",
-			itemUrl.Host,
-			anyTimeFormat("2006-01-02", item.Created, item.Updated),
-			itemUrl.Path,
-		)
-		if len(itemUrl.RawQuery) > 0 {
-			id += "?" + itemUrl.RawQuery
-		}
-		feed.Items = append(feed.Items, &feeds.Item{
-			Id:          id,
-			Title:       html.EscapeString(item.Title),
-			Link:        &feeds.Link{Href: item.Link},
-			Author:      &feeds.Author{Name: item.AuthorName},
-			Description: item.Description,
-			Created:     item.Created,
-			Updated:     item.Updated,
-			Content:     item.Content,
-		})
-	}
-	if len(feed.Items) == 0 {
-		return "", fmt.Errorf("empty feed")
-	}
-	atomFeed := (&feeds.Atom{Feed: &feed}).AtomFeed()
-	atomFeed.Icon = result.Icon
-	for i, entry := range atomFeed.Entries {
-		if entry.Author != nil {
-			entry.Author.Uri = result.Items[i].AuthorLink
-		}
+	previewSpecs := &pb.Specs{}
+	if err := json.Unmarshal(body, previewSpecs); err != nil {
+		return echo.NewHTTPError(400, fmt.Errorf("unmarshal specs: %w", err))
+	}
+	if err := specs.Validate(previewSpecs); err != nil {
+		return echo.NewHTTPError(400, err)
 	}
-	atom, err := feeds.ToXML(atomFeed)
+
+	task, err := taskFromSpecs(previewSpecs)
 	if err != nil {
-		return "", fmt.Errorf("feed to xml: %w", err)
+		return echo.NewHTTPError(400, err.Error())
 	}
-	return atom, nil
+	task.Headers = extractHeaders(c)
+	task.TaskType = map[models.TaskType]models.TaskType{
+		models.TaskTypeExtract:     models.TaskTypePreview,
+		models.TaskTypeExtractJSON: models.TaskTypePreviewJSON,
+	}[task.TaskType]
+
+	timeoutCtx, cancel := taskContext(c.Request().Context())
+	defer cancel()
+
+	encodedTask, err := json.Marshal(task)
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("task marshal error: %v", err))
+	}
+
+	if err := h.checkRateLimit(c); err != nil {
+		return err
+	}
+
+	taskResultBytes, err := h.workQueue.Enqueue(timeoutCtx, task.CacheKey(), encodedTask)
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("task enqueue failed: %v", err))
+	}
+	if err := taskError(taskResultBytes); err != nil {
+		return err
+	}
+
+	var result models.PreviewTaskResult
+	if err := json.Unmarshal(taskResultBytes, &result); err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("task result unmarshal failed: %v", err))
+	}
+	return c.JSON(200, result)
+}
+
+// taskError returns error for client if worker stored task error instead of result
+func taskError(payload []byte) error {
+	var taskErr models.TaskError
+	if err := json.Unmarshal(payload, &taskErr); err != nil || taskErr.Error == "" {
+		return nil
+	}
+	if taskErr.Kind == models.TaskErrorRejected {
+		return echo.NewHTTPError(403, taskErr.Error)
+	}
+	return echo.NewHTTPError(500, taskErr.Error)
+}
+
+// decodeSpecs decodes and validates specs param, which is either encoded specs or preset:<name>
+func (h *Handler) decodeSpecs(specsParam string) (*pb.Specs, error) {
+	return h.presets.DecodeParam(specsParam)
+}
+
+func taskFromSpecs(s *pb.Specs) (models.Task, error) {
+	return specs.ToTask(s)
 }
 
 func extractHeaders(c echo.Context) map[string]string {
@@ -308,13 +405,3 @@ func extractHeaders(c echo.Context) map[string]string {
 	}
 	return headers
 }
-
-// returns the first non-zero time formatted as a string or ""
-func anyTimeFormat(format string, times ...time.Time) string {
-	for _, t := range times {
-		if !t.IsZero() {
-			return t.Format(format)
-		}
-	}
-	return ""
-}
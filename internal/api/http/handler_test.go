@@ -0,0 +1,109 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	dummycookies "github.com/egor3f/rssalchemy/internal/cookiemgr/dummy"
+	"github.com/egor3f/rssalchemy/internal/dateparser"
+	"github.com/egor3f/rssalchemy/internal/extractors/pwextractor"
+	"github.com/egor3f/rssalchemy/internal/limiter/dummy"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// newTestServer makes server with api routes under /api/v1. Zero fields of cfg get test defaults:
+// queue and cache which fail every task, no rate limits and test presets.
+// Route /api/v1/limited only checks rate limit of client.
+func newTestServer(t *testing.T, cfg Config) *echo.Echo {
+	t.Helper()
+	if cfg.WorkQueue == nil {
+		cfg.WorkQueue = nopQueue{}
+	}
+	if cfg.Cache == nil {
+		cfg.Cache = nopQueue{}
+	}
+	if cfg.RateLimit == 0 {
+		cfg.RateLimit, cfg.RateLimitBurst = rate.Inf, 1
+	}
+	if cfg.Auth.DefaultRateLimit == 0 {
+		cfg.Auth.DefaultRateLimit, cfg.Auth.DefaultBurst = rate.Inf, 1
+	}
+	if cfg.Presets == nil {
+		cfg.Presets = testPresets(t)
+	}
+	h := New(cfg)
+	e := echo.New()
+	h.SetupRoutes(e.Group("/api/v1"))
+	e.GET("/api/v1/limited", func(c echo.Context) error {
+		if err := h.checkRateLimit(c); err != nil {
+			return err
+		}
+		return c.NoContent(200)
+	}, h.authenticate)
+	return e
+}
+
+// extractorQueue runs tasks with real extractor and encodes results like worker does
+type extractorQueue struct {
+	e *pwextractor.PwExtractor
+}
+
+func (q extractorQueue) Enqueue(ctx context.Context, _ string, payload []byte) ([]byte, error) {
+	var task models.Task
+	if err := json.Unmarshal(payload, &task); err != nil {
+		return nil, err
+	}
+	return pwextractor.TaskResultPayload(q.e.Preview(ctx, task))
+}
+
+func TestTaskError(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		status  int // 0 if payload is a regular result
+	}{
+		{"feed", `{"title": "feed", "items": []}`, 0},
+		{"screenshot", `{"image": "iVBORw0KGgo="}`, 0},
+		{"preview", `{"title": "feed", "posts_matched": 0, "posts": []}`, 0},
+		{"not json", `PNG`, 0},
+		{"rejected", `{"task_error_kind": "rejected", "task_error": "host example.com is not allowed: not in allowlist"}`, 403},
+		{"unknown kind", `{"task_error_kind": "other", "task_error": "failed"}`, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := taskError([]byte(tt.payload))
+			if tt.status == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var httpErr *echo.HTTPError
+			if assert.ErrorAs(t, err, &httpErr) {
+				assert.Equal(t, tt.status, httpErr.Code)
+			}
+		})
+	}
+
+	t.Run("preview endpoint", func(t *testing.T) {
+		// host is rejected before flaresolverr request
+		pwe, err := pwextractor.New(pwextractor.Config{
+			FlareSolverrURL: "http://127.0.0.1:1",
+			DateParser:      &dateparser.DateParser{CurrentTimeFunc: time.Now},
+			CookieManager:   dummycookies.New(),
+			Limiter:         &dummy.Limiter{},
+		})
+		require.NoError(t, err)
+		e := newTestServer(t, Config{WorkQueue: extractorQueue{pwe}})
+		body, err := json.Marshal(testSpecs("http://127.0.0.1/news"))
+		require.NoError(t, err)
+
+		rec := doRequest(e, http.MethodPost, "/api/v1/preview", string(body), nil)
+		assert.Equal(t, 403, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), "reserved address")
+	})
+}
@@ -0,0 +1,209 @@
+package http
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/egor3f/rssalchemy/internal/api/http/pb"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/specs"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	linkIDSize        = 8 // bytes of specs digest, 11 chars in url
+	linkTokenSize     = 24
+	maxLinkIDAttempts = 5
+)
+
+type linkRequest struct {
+	Specs string `json:"specs"`
+	// OwnerToken is required for update. On create it's optional: links of the same owner and specs are reused.
+	OwnerToken string `json:"owner_token,omitempty"`
+}
+
+type linkResponse struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// OwnerToken is returned only once, when link is created
+	OwnerToken string `json:"owner_token,omitempty"`
+}
+
+func newLinkResponse(id string, ownerToken string) linkResponse {
+	return linkResponse{ID: id, URL: specs.FeedPath + id, OwnerToken: ownerToken}
+}
+
+// specsDigest is hash of canonical proto encoding, same for json and proto encoded specs
+func specsDigest(s *pb.Specs) ([]byte, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("marshal specs: %w", err)
+	}
+	digest := sha256.Sum256(data)
+	return digest[:], nil
+}
+
+// linkID derives id from owner token hash and specs digest, so every owner has own link for the same specs.
+// Attempt > 0 is used when id is taken by link which was updated later.
+func linkID(tokenHash string, digest []byte, attempt int) string {
+	salted := sha256.Sum256(append([]byte(tokenHash+":"+strconv.Itoa(attempt)+":"), digest...))
+	return base64.RawURLEncoding.EncodeToString(salted[:linkIDSize])
+}
+
+func ownerTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// readLinkRequest reads and validates specs of create and update requests.
+// Specs of returned request are canonical encoding, so preset param is stored as its specs, not as reference.
+func (h *Handler) readLinkRequest(c echo.Context) (linkRequest, []byte, error) {
+	if h.links == nil {
+		return linkRequest{}, nil, echo.NewHTTPError(501, "short links are not configured")
+	}
+	// same rule as for signatures: with signing enabled only key holders can make renderable links
+	if _, hasKey := c.Get(apiKeyContextKey).(apiKeyIdentity); h.signer != nil && !hasKey {
+		return linkRequest{}, nil, echo.NewHTTPError(401, "api key required")
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxSpecsBodySize))
+	if err != nil {
+		return linkRequest{}, nil, echo.NewHTTPError(400, fmt.Errorf("read body: %w", err))
+	}
+	var req linkRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return linkRequest{}, nil, echo.NewHTTPError(400, fmt.Errorf("unmarshal request: %w", err))
+	}
+	decoded, err := h.decodeSpecs(req.Specs)
+	if err != nil {
+		return linkRequest{}, nil, echo.NewHTTPError(400, fmt.Errorf("decode specs: %w", err))
+	}
+	if _, err := taskFromSpecs(decoded); err != nil {
+		return linkRequest{}, nil, echo.NewHTTPError(400, err.Error())
+	}
+	if req.Specs, err = specs.Encode(decoded); err != nil {
+		return linkRequest{}, nil, echo.NewHTTPError(500, err)
+	}
+	digest, err := specsDigest(decoded)
+	if err != nil {
+		return linkRequest{}, nil, echo.NewHTTPError(500, err)
+	}
+	return req, digest, nil
+}
+
+// handleCreateLink stores specs under id derived from their content and owner token.
+// Without owner token in request new token is generated, so every creator gets own link which nobody else can change.
+// With owner token, link of the same owner and specs is returned if it exists.
+func (h *Handler) handleCreateLink(c echo.Context) error {
+	req, digest, err := h.readLinkRequest(c)
+	if err != nil {
+		return err
+	}
+	if err := h.checkRateLimit(c); err != nil {
+		return err
+	}
+
+	token, newToken := req.OwnerToken, ""
+	if token == "" {
+		tokenBytes := make([]byte, linkTokenSize)
+		if _, err := rand.Read(tokenBytes); err != nil {
+			return echo.NewHTTPError(500, fmt.Errorf("generate owner token: %w", err))
+		}
+		token = base64.RawURLEncoding.EncodeToString(tokenBytes)
+		newToken = token
+	}
+	now := time.Now().UTC()
+	link := models.ShortLink{
+		Specs:          req.Specs,
+		OwnerTokenHash: ownerTokenHash(token),
+		Created:        now,
+		Updated:        now,
+	}
+
+	for attempt := range maxLinkIDAttempts {
+		id := linkID(link.OwnerTokenHash, digest, attempt)
+		err := h.links.CreateLink(id, link)
+		if err == nil {
+			log.Infof("created short link id=%s", id)
+			return c.JSON(201, newLinkResponse(id, newToken))
+		}
+		if !errors.Is(err, adapters.ErrKeyExists) {
+			return echo.NewHTTPError(500, fmt.Errorf("create link: %w", err))
+		}
+		existing, err := h.links.GetLink(id)
+		if err != nil {
+			return echo.NewHTTPError(500, fmt.Errorf("get link: %w", err))
+		}
+		if existing.OwnerTokenHash == link.OwnerTokenHash && h.linkDigestEquals(existing, digest) {
+			return c.JSON(200, newLinkResponse(id, ""))
+		}
+	}
+	return echo.NewHTTPError(500, "no free link id")
+}
+
+func (h *Handler) linkDigestEquals(link models.ShortLink, digest []byte) bool {
+	decoded, err := h.decodeSpecs(link.Specs)
+	if err != nil {
+		return false
+	}
+	existingDigest, err := specsDigest(decoded)
+	return err == nil && bytes.Equal(existingDigest, digest)
+}
+
+// handleUpdateLink replaces specs behind id, so subscriptions get new feed without changing url
+func (h *Handler) handleUpdateLink(c echo.Context) error {
+	req, _, err := h.readLinkRequest(c)
+	if err != nil {
+		return err
+	}
+	id := c.Param("id")
+	link, err := h.links.GetLink(id)
+	if errors.Is(err, adapters.ErrKeyNotFound) {
+		return echo.NewHTTPError(404, "link not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("get link: %w", err))
+	}
+	if req.OwnerToken == "" ||
+		subtle.ConstantTimeCompare([]byte(ownerTokenHash(req.OwnerToken)), []byte(link.OwnerTokenHash)) != 1 {
+		return echo.NewHTTPError(403, "invalid owner token")
+	}
+	link.Specs = req.Specs
+	link.Updated = time.Now().UTC()
+	if err := h.links.PutLink(id, link); err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("put link: %w", err))
+	}
+	log.Infof("updated short link id=%s", id)
+	return c.JSON(200, newLinkResponse(id, ""))
+}
+
+// handleFeed renders feed of short link, exactly like render with its specs.
+// Signature is not required: links are created only by those who could sign.
+func (h *Handler) handleFeed(c echo.Context) error {
+	if h.links == nil {
+		return echo.NewHTTPError(404, "link not found")
+	}
+	link, err := h.links.GetLink(c.Param("id"))
+	if errors.Is(err, adapters.ErrKeyNotFound) {
+		return echo.NewHTTPError(404, "link not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("get link: %w", err))
+	}
+	decoded, err := h.decodeSpecs(link.Specs)
+	if err != nil {
+		return echo.NewHTTPError(500, fmt.Errorf("decode stored specs: %w", err))
+	}
+	return h.renderSpecs(c, decoded)
+}
@@ -0,0 +1,193 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/egor3f/rssalchemy/internal/api/http/pb"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memLinkStore struct {
+	mu    sync.Mutex
+	links map[string]models.ShortLink
+}
+
+func (s *memLinkStore) GetLink(id string) (models.ShortLink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	link, ok := s.links[id]
+	if !ok {
+		return models.ShortLink{}, adapters.ErrKeyNotFound
+	}
+	return link, nil
+}
+
+func (s *memLinkStore) CreateLink(id string, link models.ShortLink) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.links[id]; ok {
+		return adapters.ErrKeyExists
+	}
+	s.links[id] = link
+	return nil
+}
+
+func (s *memLinkStore) PutLink(id string, link models.ShortLink) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.links[id] = link
+	return nil
+}
+
+// feedQueue renders every task as feed with title from task url and remembers cache keys
+type feedQueue struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (q *feedQueue) Enqueue(_ context.Context, key string, payload []byte) ([]byte, error) {
+	var task models.Task
+	if err := json.Unmarshal(payload, &task); err != nil {
+		return nil, err
+	}
+	q.mu.Lock()
+	q.keys = append(q.keys, key)
+	q.mu.Unlock()
+	return json.Marshal(models.TaskResult{Title: task.URL, Items: []models.FeedItem{
+		{Title: "post", Link: task.URL + "post", Created: time.Now()},
+	}})
+}
+
+func newMemLinkStore() *memLinkStore {
+	return &memLinkStore{links: make(map[string]models.ShortLink)}
+}
+
+// teamAuth has static key team-secret
+var teamAuth = AuthConfig{StaticKeys: []string{"team:team-secret"}}
+
+func testSpecs(url string) *pb.Specs {
+	return &pb.Specs{
+		Url:               url,
+		SelectorPost:      ".post",
+		SelectorTitle:     ".title",
+		SelectorLink:      "a",
+		SelectorCreated:   ".date",
+		SelectorEnclosure: "img",
+		CacheLifetime:     "10m",
+	}
+}
+
+func TestShortLinks(t *testing.T) {
+	queue := &feedQueue{}
+	e := newTestServer(t, Config{WorkQueue: queue, Auth: teamAuth, Links: newMemLinkStore()})
+	specs := encodeTestSpecs(t, testSpecs("https://example.com/"))
+
+	rec := doRequest(e, http.MethodPost, "/api/v1/links", `{"specs": "`+specs+`"}`, nil)
+	require.Equal(t, 201, rec.Code, rec.Body.String())
+	var created linkResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Len(t, created.ID, 11)
+	assert.Equal(t, "/api/v1/feed/"+created.ID, created.URL)
+	assert.NotEmpty(t, created.OwnerToken)
+
+	// same specs of another creator give another link, so it can't be changed by the first owner
+	rec = doRequest(e, http.MethodPost, "/api/v1/links", `{"specs": "`+specs+`"}`, nil)
+	require.Equal(t, 201, rec.Code)
+	var other linkResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &other))
+	assert.NotEqual(t, created.ID, other.ID)
+	assert.NotEqual(t, created.OwnerToken, other.OwnerToken)
+
+	// same owner and specs give the same link, token is not revealed again
+	rec = doRequest(e, http.MethodPost, "/api/v1/links",
+		`{"specs": "`+specs+`", "owner_token": "`+created.OwnerToken+`"}`, nil)
+	require.Equal(t, 200, rec.Code)
+	var again linkResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &again))
+	assert.Equal(t, created.ID, again.ID)
+	assert.Empty(t, again.OwnerToken)
+
+	// feed is rendered exactly like render url
+	rec = doRequest(e, http.MethodGet, created.URL, "", nil)
+	require.Equal(t, 200, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "https://example.com/post")
+	rec = doRequest(e, http.MethodGet, "/api/v1/render/"+specs, "", nil)
+	require.Equal(t, 200, rec.Code)
+	assert.Equal(t, queue.keys[0], queue.keys[1])
+
+	newSpecs := encodeTestSpecs(t, testSpecs("https://example.org/"))
+	rec = doRequest(e, http.MethodPut, "/api/v1/links/"+created.ID, `{"specs": "`+newSpecs+`", "owner_token": "wrong"}`, nil)
+	assert.Equal(t, 403, rec.Code)
+	rec = doRequest(e, http.MethodPut, "/api/v1/links/unknown", `{"specs": "`+newSpecs+`", "owner_token": "x"}`, nil)
+	assert.Equal(t, 404, rec.Code)
+	rec = doRequest(e, http.MethodPut, "/api/v1/links/"+created.ID,
+		`{"specs": "`+newSpecs+`", "owner_token": "`+created.OwnerToken+`"}`, nil)
+	require.Equal(t, 200, rec.Code, rec.Body.String())
+
+	rec = doRequest(e, http.MethodGet, created.URL, "", nil)
+	require.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Body.String(), "https://example.org/post")
+
+	// other owner's link is not affected by update
+	rec = doRequest(e, http.MethodGet, other.URL, "", nil)
+	require.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Body.String(), "https://example.com/post")
+
+	// original specs of the owner now point to updated link, so new id is allocated for them
+	rec = doRequest(e, http.MethodPost, "/api/v1/links",
+		`{"specs": "`+specs+`", "owner_token": "`+created.OwnerToken+`"}`, nil)
+	require.Equal(t, 201, rec.Code)
+	var recreated linkResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &recreated))
+	assert.NotEqual(t, created.ID, recreated.ID)
+	assert.Empty(t, recreated.OwnerToken)
+}
+
+func TestShortLinksStoreCanonicalSpecs(t *testing.T) {
+	store := newMemLinkStore()
+	e := newTestServer(t, Config{WorkQueue: &feedQueue{}, Links: store})
+
+	// preset is stored as its specs, so link doesn't change with preset
+	rec := doRequest(e, http.MethodPost, "/api/v1/links", `{"specs": "preset:example"}`, nil)
+	require.Equal(t, 201, rec.Code, rec.Body.String())
+	var created linkResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	link, err := store.GetLink(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, encodeTestSpecs(t, testSpecs("https://example.com/")), link.Specs)
+}
+
+func TestShortLinksValidation(t *testing.T) {
+	e := newTestServer(t, Config{WorkQueue: &feedQueue{}, Auth: teamAuth, Links: newMemLinkStore()})
+	rec := doRequest(e, http.MethodPost, "/api/v1/links", `{"specs": "garbage"}`, nil)
+	assert.Equal(t, 400, rec.Code)
+	rec = doRequest(e, http.MethodGet, "/api/v1/feed/unknown", "", nil)
+	assert.Equal(t, 404, rec.Code)
+}
+
+func TestShortLinksWithSigning(t *testing.T) {
+	signer, err := signing.New([]string{"k1:0123456789abcdef"})
+	require.NoError(t, err)
+	e := newTestServer(t, Config{WorkQueue: &feedQueue{}, Auth: teamAuth, Signer: signer, Links: newMemLinkStore()})
+	body := `{"specs": "` + encodeTestSpecs(t, testSpecs("https://example.com/")) + `"}`
+
+	rec := doRequest(e, http.MethodPost, "/api/v1/links", body, nil)
+	assert.Equal(t, 401, rec.Code)
+	rec = doRequest(e, http.MethodPost, "/api/v1/links", body, map[string]string{"X-Api-Key": "team-secret"})
+	require.Equal(t, 201, rec.Code)
+	var created linkResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	// link itself doesn't need signature
+	rec = doRequest(e, http.MethodGet, created.URL, "", nil)
+	assert.Equal(t, 200, rec.Code)
+}
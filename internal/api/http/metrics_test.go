@@ -0,0 +1,34 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(MetricsMiddleware)
+	e.GET("/test/render/:specs", func(c echo.Context) error {
+		if c.Param("specs") == "bad" {
+			return echo.NewHTTPError(400, "bad specs")
+		}
+		return c.NoContent(200)
+	})
+
+	doRequest(e, http.MethodGet, "/test/render/good", "", nil)
+	doRequest(e, http.MethodGet, "/test/render/bad", "", nil)
+	doRequest(e, http.MethodGet, "/test/render/bad", "", nil)
+
+	rec := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	out := rec.Body.String()
+	assert.Contains(t, out, `rssalchemy_http_requests_total{method="GET",route="/test/render/:specs",status="200"} 1`)
+	assert.Contains(t, out, `rssalchemy_http_requests_total{method="GET",route="/test/render/:specs",status="400"} 2`)
+	assert.Contains(t, out, `rssalchemy_http_request_duration_seconds_count{method="GET",route="/test/render/:specs"} 3`)
+	// runtime metrics of default registry are kept
+	assert.Contains(t, out, "go_goroutines")
+}
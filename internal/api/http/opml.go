@@ -0,0 +1,309 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/egor3f/rssalchemy/internal/api/http/pb"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/opml"
+	"github.com/egor3f/rssalchemy/internal/specs"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	maxOPMLFeeds    = 500
+	maxOPMLBodySize = 1 << 20
+	// opmlWarmConcurrency is number of feeds of imported opml rendered at the same time
+	opmlWarmConcurrency = 4
+)
+
+const (
+	opmlStatusOK      = "ok"
+	opmlStatusBroken  = "broken"
+	opmlStatusSkipped = "skipped"
+)
+
+type opmlExportRequest struct {
+	Title string `json:"title"`
+	// Specs are encoded specs or preset:<name>, like render url param
+	Specs []string `json:"specs"`
+	// Links are short link ids
+	Links []string `json:"links"`
+	// BaseURL is public url of instance, taken from request if empty
+	BaseURL string `json:"base_url,omitempty"`
+}
+
+type opmlImportFeed struct {
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Items  int    `json:"items,omitempty"`
+	// Cached is true if result was in cache already, otherwise it's rendered by import
+	Cached bool `json:"cached,omitempty"`
+}
+
+type opmlImportResponse struct {
+	Feeds   []opmlImportFeed `json:"feeds"`
+	OK      int              `json:"ok"`
+	Broken  int              `json:"broken"`
+	Skipped int              `json:"skipped"`
+}
+
+// handleExportOPML makes OPML of specs and short links with render urls and titles from cached results.
+// Render urls are signed if signing is enabled, so only api key holders can export specs then.
+func (h *Handler) handleExportOPML(c echo.Context) error {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxOPMLBodySize))
+	if err != nil {
+		return echo.NewHTTPError(400, fmt.Errorf("read body: %w", err))
+	}
+	var req opmlExportRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return echo.NewHTTPError(400, fmt.Errorf("unmarshal request: %w", err))
+	}
+	if len(req.Specs)+len(req.Links) == 0 {
+		return echo.NewHTTPError(400, "specs or links are required")
+	}
+	if len(req.Specs)+len(req.Links) > maxOPMLFeeds {
+		return echo.NewHTTPError(400, fmt.Sprintf("too many feeds, max %d", maxOPMLFeeds))
+	}
+	if _, hasKey := c.Get(apiKeyContextKey).(apiKeyIdentity); h.signer != nil && !hasKey && len(req.Specs) > 0 {
+		return echo.NewHTTPError(401, "api key required")
+	}
+	baseURL := strings.TrimSuffix(req.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = c.Scheme() + "://" + c.Request().Host
+	}
+
+	feeds := make([]opml.Feed, 0, len(req.Specs)+len(req.Links))
+	for _, specsParam := range req.Specs {
+		decoded, err := h.decodeSpecs(specsParam)
+		if err != nil {
+			return echo.NewHTTPError(400, fmt.Errorf("decode specs %s: %w", specsParam, err))
+		}
+		xmlURL := baseURL + specs.RenderPath + specsParam
+		if h.signer != nil {
+			xmlURL += "?" + signatureQueryParam + "=" + url.QueryEscape(h.signer.Sign(specsParam))
+		}
+		feed, err := h.opmlFeed(decoded, xmlURL)
+		if err != nil {
+			return err
+		}
+		feeds = append(feeds, feed)
+	}
+	for _, id := range req.Links {
+		if h.links == nil {
+			return echo.NewHTTPError(501, "short links are not configured")
+		}
+		link, err := h.links.GetLink(id)
+		if errors.Is(err, adapters.ErrKeyNotFound) {
+			return echo.NewHTTPError(404, fmt.Sprintf("link %s not found", id))
+		}
+		if err != nil {
+			return echo.NewHTTPError(500, fmt.Errorf("get link: %w", err))
+		}
+		decoded, err := h.decodeSpecs(link.Specs)
+		if err != nil {
+			return echo.NewHTTPError(500, fmt.Errorf("decode stored specs: %w", err))
+		}
+		feed, err := h.opmlFeed(decoded, baseURL+specs.FeedPath+id)
+		if err != nil {
+			return err
+		}
+		feeds = append(feeds, feed)
+	}
+
+	title := req.Title
+	if title == "" {
+		title = "rssalchemy feeds"
+	}
+	var buf bytes.Buffer
+	if err := opml.New(title, feeds).Write(&buf); err != nil {
+		return echo.NewHTTPError(500, err)
+	}
+	return c.Blob(200, opml.ContentType, buf.Bytes())
+}
+
+// opmlFeed takes title from cached result of specs without request headers, task url if it's not cached
+func (h *Handler) opmlFeed(decoded *pb.Specs, xmlURL string) (opml.Feed, error) {
+	task, err := taskFromSpecs(decoded)
+	if err != nil {
+		return opml.Feed{}, echo.NewHTTPError(400, err.Error())
+	}
+	feed := opml.Feed{Title: task.URL, XMLURL: xmlURL, HTMLURL: task.URL}
+	cached, _, err := h.cache.Get(task.CacheKey())
+	if err != nil {
+		if !errors.Is(err, adapters.ErrKeyNotFound) {
+			log.Warnf("opml export: cache get: %v", err)
+		}
+		return feed, nil
+	}
+	var result models.TaskResult
+	if err := json.Unmarshal(cached, &result); err == nil && result.Title != "" {
+		feed.Title = result.Title
+	}
+	return feed, nil
+}
+
+// handleImportOPML validates every rssalchemy url of OPML and renders feeds which are not cached yet
+// (unless warm=false), so subscriptions work right after migration. Other urls are skipped.
+func (h *Handler) handleImportOPML(c echo.Context) error {
+	doc, err := opml.Parse(io.LimitReader(c.Request().Body, maxOPMLBodySize))
+	if err != nil {
+		return echo.NewHTTPError(400, err.Error())
+	}
+	feeds := doc.Feeds()
+	if len(feeds) > maxOPMLFeeds {
+		return echo.NewHTTPError(400, fmt.Sprintf("too many feeds, max %d", maxOPMLFeeds))
+	}
+	warm := c.QueryParam("warm") != "false"
+
+	resp := opmlImportResponse{Feeds: make([]opmlImportFeed, len(feeds))}
+	sem := make(chan struct{}, opmlWarmConcurrency)
+	var wg sync.WaitGroup
+	for i, feed := range feeds {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			resp.Feeds[i] = h.importFeed(c, feed, warm)
+		}()
+	}
+	wg.Wait()
+
+	for _, feed := range resp.Feeds {
+		switch feed.Status {
+		case opmlStatusOK:
+			resp.OK++
+		case opmlStatusBroken:
+			resp.Broken++
+		case opmlStatusSkipped:
+			resp.Skipped++
+		}
+	}
+	log.Infof("opml import: ok=%d broken=%d skipped=%d", resp.OK, resp.Broken, resp.Skipped)
+	return c.JSON(200, resp)
+}
+
+func (h *Handler) importFeed(c echo.Context, feed opml.Feed, warm bool) opmlImportFeed {
+	result := opmlImportFeed{Title: feed.Title, URL: feed.XMLURL}
+	broken := func(err error) opmlImportFeed {
+		result.Status = opmlStatusBroken
+		result.Error = err.Error()
+		return result
+	}
+
+	specsParam, isFeed, err := h.importSpecsParam(c, feed.XMLURL)
+	if !isFeed {
+		result.Status = opmlStatusSkipped
+		result.Error = "not a rssalchemy url"
+		return result
+	}
+	if err != nil {
+		return broken(err)
+	}
+	decoded, err := h.decodeSpecs(specsParam)
+	if err != nil {
+		return broken(fmt.Errorf("decode specs: %w", err))
+	}
+	task, err := taskFromSpecs(decoded)
+	if err != nil {
+		return broken(err)
+	}
+	if !warm {
+		result.Status = opmlStatusOK
+		return result
+	}
+
+	taskResultBytes, _, err := h.cache.Get(task.CacheKey())
+	result.Cached = err == nil
+	if errors.Is(err, adapters.ErrKeyNotFound) {
+		if err := h.checkRateLimit(c); err != nil {
+			result.Status = opmlStatusSkipped
+			result.Error = "rate limit exceeded, feed is not checked"
+			return result
+		}
+		encodedTask, err := json.Marshal(task)
+		if err != nil {
+			return broken(fmt.Errorf("task marshal error: %w", err))
+		}
+		timeoutCtx, cancel := taskContext(c.Request().Context())
+		defer cancel()
+		taskResultBytes, err = h.workQueue.Enqueue(timeoutCtx, task.CacheKey(), encodedTask)
+		if err != nil {
+			return broken(fmt.Errorf("task enqueue failed: %w", err))
+		}
+	} else if err != nil {
+		return broken(fmt.Errorf("cache failed: %w", err))
+	}
+	if err := taskError(taskResultBytes); err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			err = fmt.Errorf("%v", httpErr.Message)
+		}
+		return broken(err)
+	}
+	var taskResult models.TaskResult
+	if err := json.Unmarshal(taskResultBytes, &taskResult); err != nil {
+		return broken(fmt.Errorf("result unmarshal failed: %w", err))
+	}
+	if len(taskResult.Items) == 0 {
+		return broken(fmt.Errorf("empty feed"))
+	}
+	result.Status = opmlStatusOK
+	result.Items = len(taskResult.Items)
+	if taskResult.Title != "" {
+		result.Title = taskResult.Title
+	}
+	return result
+}
+
+// importSpecsParam returns specs param of render url or short link url.
+// Signature of render url is checked like on render, because import renders feeds too.
+func (h *Handler) importSpecsParam(c echo.Context, xmlURL string) (string, bool, error) {
+	u, err := url.Parse(xmlURL)
+	if err != nil {
+		return "", false, nil
+	}
+	if _, id, isLink := strings.Cut(u.Path, specs.FeedPath); isLink && id != "" {
+		if h.links == nil {
+			return "", true, fmt.Errorf("short links are not configured")
+		}
+		link, err := h.links.GetLink(id)
+		if errors.Is(err, adapters.ErrKeyNotFound) {
+			return "", true, fmt.Errorf("link not found")
+		}
+		if err != nil {
+			return "", true, fmt.Errorf("get link: %w", err)
+		}
+		return link.Specs, true, nil
+	}
+	if !strings.Contains(u.Path, specs.RenderPath) {
+		return "", false, nil
+	}
+	specsParam, err := specs.RenderParam(xmlURL)
+	if err != nil {
+		return "", true, err
+	}
+	if _, hasKey := c.Get(apiKeyContextKey).(apiKeyIdentity); h.signer != nil && !hasKey {
+		sig := u.Query().Get(signatureQueryParam)
+		if sig == "" {
+			return "", true, fmt.Errorf("signature required")
+		}
+		if err := h.signer.Verify(specsParam, sig); err != nil {
+			return "", true, err
+		}
+	}
+	return specsParam, true, nil
+}
@@ -0,0 +1,230 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/egor3f/rssalchemy/internal/adapters"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/opml"
+	"github.com/egor3f/rssalchemy/internal/signing"
+	"github.com/egor3f/rssalchemy/internal/specs"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func (m *memCache) Get(key string) ([]byte, time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.entries[key]
+	if !ok {
+		return nil, time.Time{}, adapters.ErrKeyNotFound
+	}
+	return value, time.Now(), nil
+}
+
+func (m *memCache) Set(key string, payload []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = payload
+	return nil
+}
+
+type opmlTestServer struct {
+	e     *echo.Echo
+	queue *feedQueue
+	cache *memCache
+	links *memLinkStore
+}
+
+func newOPMLTestServer(t *testing.T, signer *signing.Signer) opmlTestServer {
+	t.Helper()
+	s := opmlTestServer{
+		queue: &feedQueue{},
+		cache: &memCache{entries: make(map[string][]byte)},
+		links: newMemLinkStore(),
+	}
+	s.e = newTestServer(t, Config{WorkQueue: s.queue, Cache: s.cache, Auth: teamAuth, Signer: signer, Links: s.links})
+	return s
+}
+
+func (s opmlTestServer) cacheResult(t *testing.T, encodedSpecs string, result models.TaskResult) {
+	t.Helper()
+	decoded, err := specs.Decode(encodedSpecs)
+	require.NoError(t, err)
+	task, err := specs.ToTask(decoded)
+	require.NoError(t, err)
+	payload, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.NoError(t, s.cache.Set(task.CacheKey(), payload))
+}
+
+func exportRequest(t *testing.T, req opmlExportRequest) string {
+	t.Helper()
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestExportOPML(t *testing.T) {
+	s := newOPMLTestServer(t, nil)
+	cachedSpecs := encodeTestSpecs(t, testSpecs("https://example.com/"))
+	s.cacheResult(t, cachedSpecs, models.TaskResult{Title: "Example news"})
+	otherSpecs := encodeTestSpecs(t, testSpecs("https://example.org/"))
+	s.links.links["AbCdEfGhIjK"] = models.ShortLink{Specs: otherSpecs}
+
+	rec := doRequest(s.e, http.MethodPost, "/api/v1/opml/export", exportRequest(t, opmlExportRequest{
+		Title: "team feeds",
+		Specs: []string{cachedSpecs, "preset:example"},
+		Links: []string{"AbCdEfGhIjK"},
+	}), nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, opml.ContentType, rec.Header().Get("Content-Type"))
+
+	doc, err := opml.Parse(rec.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "team feeds", doc.Head.Title)
+	feeds := doc.Feeds()
+	require.Len(t, feeds, 3)
+	assert.Equal(t, opml.Feed{
+		Title:   "Example news",
+		XMLURL:  "http://example.com/api/v1/render/" + cachedSpecs,
+		HTMLURL: "https://example.com/",
+	}, feeds[0])
+	assert.Equal(t, "http://example.com/api/v1/render/preset:example", feeds[1].XMLURL)
+	// not cached: title is page url
+	assert.Equal(t, opml.Feed{
+		Title:   "https://example.org/",
+		XMLURL:  "http://example.com/api/v1/feed/AbCdEfGhIjK",
+		HTMLURL: "https://example.org/",
+	}, feeds[2])
+	assert.Empty(t, s.queue.keys, "export must not render feeds")
+
+	tests := []struct {
+		name     string
+		req      opmlExportRequest
+		wantCode int
+	}{
+		{"empty", opmlExportRequest{}, http.StatusBadRequest},
+		{"invalid specs", opmlExportRequest{Specs: []string{"1:garbage"}}, http.StatusBadRequest},
+		{"unknown link", opmlExportRequest{Links: []string{"unknown"}}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(s.e, http.MethodPost, "/api/v1/opml/export", exportRequest(t, tt.req), nil)
+			assert.Equal(t, tt.wantCode, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestExportOPMLSigned(t *testing.T) {
+	signer, err := signing.New([]string{"k1:0123456789abcdef"})
+	require.NoError(t, err)
+	s := newOPMLTestServer(t, signer)
+	encoded := encodeTestSpecs(t, testSpecs("https://example.com/"))
+	body := exportRequest(t, opmlExportRequest{Specs: []string{encoded}, BaseURL: "https://rss.example.com/"})
+
+	rec := doRequest(s.e, http.MethodPost, "/api/v1/opml/export", body, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = doRequest(s.e, http.MethodPost, "/api/v1/opml/export", body, map[string]string{"X-Api-Key": "team-secret"})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	doc, err := opml.Parse(rec.Body)
+	require.NoError(t, err)
+	require.Len(t, doc.Feeds(), 1)
+	xmlURL, err := url.Parse(doc.Feeds()[0].XMLURL)
+	require.NoError(t, err)
+	assert.Equal(t, "rss.example.com", xmlURL.Host)
+	assert.NoError(t, signer.Verify(encoded, xmlURL.Query().Get("sig")))
+}
+
+func importOPML(feeds ...opml.Feed) string {
+	var b strings.Builder
+	_ = opml.New("import", feeds).Write(&b)
+	return b.String()
+}
+
+func TestImportOPML(t *testing.T) {
+	s := newOPMLTestServer(t, nil)
+	cachedSpecs := encodeTestSpecs(t, testSpecs("https://example.com/"))
+	s.cacheResult(t, cachedSpecs, models.TaskResult{Title: "Cached", Items: []models.FeedItem{{Title: "post"}}})
+	newSpecs := encodeTestSpecs(t, testSpecs("https://example.org/"))
+	s.links.links["AbCdEfGhIjK"] = models.ShortLink{Specs: newSpecs}
+	emptySpecs := encodeTestSpecs(t, testSpecs("https://example.net/"))
+	s.cacheResult(t, emptySpecs, models.TaskResult{Title: "Empty"})
+
+	body := importOPML(
+		opml.Feed{Title: "cached", XMLURL: "https://rss.example.com/api/v1/render/" + cachedSpecs},
+		opml.Feed{Title: "link", XMLURL: "https://rss.example.com/api/v1/feed/AbCdEfGhIjK"},
+		opml.Feed{Title: "other", XMLURL: "https://blog.example.com/atom.xml"},
+		opml.Feed{Title: "garbage", XMLURL: "https://rss.example.com/api/v1/render/1:garbage"},
+		opml.Feed{Title: "unknown link", XMLURL: "https://rss.example.com/api/v1/feed/unknown"},
+		opml.Feed{Title: "empty", XMLURL: "https://rss.example.com/api/v1/render/" + emptySpecs},
+	)
+	rec := doRequest(s.e, http.MethodPost, "/api/v1/opml/import", body, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp opmlImportResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+	assert.Equal(t, 2, resp.OK)
+	assert.Equal(t, 3, resp.Broken)
+	assert.Equal(t, 1, resp.Skipped)
+	require.Len(t, resp.Feeds, 6)
+	assert.Equal(t, opmlImportFeed{
+		Title: "Cached", URL: "https://rss.example.com/api/v1/render/" + cachedSpecs, Status: "ok", Items: 1, Cached: true,
+	}, resp.Feeds[0])
+	assert.Equal(t, opmlImportFeed{
+		Title: "https://example.org/", URL: "https://rss.example.com/api/v1/feed/AbCdEfGhIjK", Status: "ok", Items: 1,
+	}, resp.Feeds[1])
+	assert.Equal(t, "skipped", resp.Feeds[2].Status)
+	assert.Equal(t, "broken", resp.Feeds[3].Status)
+	assert.Contains(t, resp.Feeds[3].Error, "decode specs")
+	assert.Equal(t, "link not found", resp.Feeds[4].Error)
+	assert.Equal(t, "empty feed", resp.Feeds[5].Error)
+	// only not cached feed is rendered
+	assert.Len(t, s.queue.keys, 1)
+
+	rec = doRequest(s.e, http.MethodPost, "/api/v1/opml/import?warm=false", body, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 3, resp.OK, "empty feed is not detected without rendering")
+	assert.Len(t, s.queue.keys, 1)
+
+	rec = doRequest(s.e, http.MethodPost, "/api/v1/opml/import", "not opml", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestImportOPMLSigned(t *testing.T) {
+	signer, err := signing.New([]string{"k1:0123456789abcdef"})
+	require.NoError(t, err)
+	s := newOPMLTestServer(t, signer)
+	encoded := encodeTestSpecs(t, testSpecs("https://example.com/"))
+	renderURL := "https://rss.example.com/api/v1/render/" + encoded
+	body := importOPML(
+		opml.Feed{Title: "unsigned", XMLURL: renderURL},
+		opml.Feed{Title: "signed", XMLURL: renderURL + "?sig=" + url.QueryEscape(signer.Sign(encoded))},
+	)
+
+	rec := doRequest(s.e, http.MethodPost, "/api/v1/opml/import", body, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp opmlImportResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Feeds, 2)
+	assert.Equal(t, "signature required", resp.Feeds[0].Error)
+	assert.Equal(t, "ok", resp.Feeds[1].Status)
+
+	// key holders can import anything, like they can render anything
+	rec = doRequest(s.e, http.MethodPost, "/api/v1/opml/import", body, map[string]string{"X-Api-Key": "team-secret"})
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.OK)
+}
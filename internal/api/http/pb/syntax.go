@@ -0,0 +1,25 @@
+package pb
+
+import "github.com/egor3f/rssalchemy/internal/validators"
+
+// SelectorSyntax tells validators how to interpret selector fields:
+// specs with json source use JMESPath expressions, html selectors are CSS or XPath depending on field type
+func (x *Specs) SelectorSyntax(fieldName string) validators.SelectorSyntax {
+	if x.GetSourceType() == SourceType_Json {
+		return validators.SyntaxJmesPath
+	}
+	fieldTypes := map[string]SelectorType{
+		"SelectorPost":        x.GetSelectorPostType(),
+		"SelectorTitle":       x.GetSelectorTitleType(),
+		"SelectorLink":        x.GetSelectorLinkType(),
+		"SelectorDescription": x.GetSelectorDescriptionType(),
+		"SelectorAuthor":      x.GetSelectorAuthorType(),
+		"SelectorCreated":     x.GetSelectorCreatedType(),
+		"SelectorContent":     x.GetSelectorContentType(),
+		"SelectorEnclosure":   x.GetSelectorEnclosureType(),
+	}
+	if fieldTypes[fieldName] == SelectorType_XPath {
+		return validators.SyntaxXPath
+	}
+	return validators.SyntaxCSS
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"github.com/egor3f/rssalchemy/internal/presets"
+	"github.com/egor3f/rssalchemy/internal/specs"
+	"github.com/labstack/echo/v4"
+)
+
+type presetResponse struct {
+	presets.Preset
+	// RenderURL is relative url of feed
+	RenderURL string `json:"render_url"`
+}
+
+func newPresetResponse(preset presets.Preset) presetResponse {
+	return presetResponse{Preset: preset, RenderURL: specs.RenderPath + presets.ParamPrefix + preset.Name}
+}
+
+// handleListPresets returns presets matching optional q (name or description) and tag params
+func (h *Handler) handleListPresets(c echo.Context) error {
+	found := h.presets.Search(c.QueryParam("q"), c.QueryParam("tag"))
+	result := make([]presetResponse, 0, len(found))
+	for _, preset := range found {
+		result = append(result, newPresetResponse(preset))
+	}
+	return c.JSON(200, result)
+}
+
+func (h *Handler) handleGetPreset(c echo.Context) error {
+	preset, ok := h.presets.Get(c.Param("name"))
+	if !ok {
+		return echo.NewHTTPError(404, presets.ErrNotFound.Error())
+	}
+	return c.JSON(200, newPresetResponse(preset))
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/egor3f/rssalchemy/internal/presets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+	"testing/fstest"
+)
+
+// testPresets returns builtin presets and "example" preset with testSpecs
+func testPresets(t *testing.T) *presets.Registry {
+	t.Helper()
+	registry, err := presets.New("")
+	require.NoError(t, err)
+	require.NoError(t, registry.Load(fstest.MapFS{
+		"example.yaml": {Data: []byte(
+			"name: example\ndescription: Example news\ntags: [test]\nspec: rssalchemy:" +
+				encodeTestSpecs(t, testSpecs("https://example.com/")),
+		)},
+	}))
+	return registry
+}
+
+func TestPresets(t *testing.T) {
+	e := newTestServer(t, Config{WorkQueue: &feedQueue{}})
+
+	listNames := func(query string) []string {
+		rec := doRequest(e, http.MethodGet, "/api/v1/presets"+query, "", nil)
+		require.Equal(t, 200, rec.Code, rec.Body.String())
+		var result []presetResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		names := []string{}
+		for _, preset := range result {
+			names = append(names, preset.Name)
+		}
+		return names
+	}
+	assert.Contains(t, listNames(""), "example")
+	assert.Equal(t, []string{"example"}, listNames("?tag=test"))
+	assert.Equal(t, []string{"example"}, listNames("?q=EXAMPLE+news"))
+	assert.Empty(t, listNames("?q=example&tag=video"))
+
+	rec := doRequest(e, http.MethodGet, "/api/v1/presets/example", "", nil)
+	require.Equal(t, 200, rec.Code)
+	var preset presetResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &preset))
+	assert.Equal(t, "/api/v1/render/preset:example", preset.RenderURL)
+	rec = doRequest(e, http.MethodGet, "/api/v1/presets/missing", "", nil)
+	assert.Equal(t, 404, rec.Code)
+
+	rec = doRequest(e, http.MethodGet, preset.RenderURL, "", nil)
+	require.Equal(t, 200, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "https://example.com/post")
+	rec = doRequest(e, http.MethodGet, "/api/v1/render/preset:missing", "", nil)
+	assert.Equal(t, 404, rec.Code)
+
+	// spec copied from wizard is accepted by render too
+	rec = doRequest(e, http.MethodGet, "/api/v1/render/"+preset.Spec, "", nil)
+	assert.Equal(t, 200, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/url"
+)
+
+const signatureQueryParam = "sig"
+
+// checkSignature verifies signature of specs (or url) param if signing is enabled.
+// Requests with api key are not checked, key holders can sign anything anyway.
+func (h *Handler) checkSignature(c echo.Context, value string) error {
+	if h.signer == nil {
+		return nil
+	}
+	if _, hasKey := c.Get(apiKeyContextKey).(apiKeyIdentity); hasKey {
+		return nil
+	}
+	sig := c.QueryParam(signatureQueryParam)
+	if sig == "" {
+		return echo.NewHTTPError(403, "signature required")
+	}
+	if err := h.signer.Verify(value, sig); err != nil {
+		return echo.NewHTTPError(403, err.Error())
+	}
+	return nil
+}
+
+type signRequest struct {
+	Specs string `json:"specs"`
+	URL   string `json:"url"`
+}
+
+type signResponse struct {
+	Sig string `json:"sig"`
+}
+
+// handleSign signs encoded specs or url for screenshot. Only api key holders can sign,
+// so wizard of instance with signing enabled must be opened with key param.
+func (h *Handler) handleSign(c echo.Context) error {
+	if h.signer == nil {
+		return echo.NewHTTPError(404, "signing is disabled")
+	}
+	if _, hasKey := c.Get(apiKeyContextKey).(apiKeyIdentity); !hasKey {
+		return echo.NewHTTPError(401, "api key required")
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxSpecsBodySize))
+	if err != nil {
+		return echo.NewHTTPError(400, fmt.Errorf("read body: %w", err))
+	}
+	var req signRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return echo.NewHTTPError(400, fmt.Errorf("unmarshal request: %w", err))
+	}
+
+	var value string
+	switch {
+	case req.Specs != "" && req.URL != "":
+		return echo.NewHTTPError(400, "either specs or url must be set, not both")
+	case req.Specs != "":
+		if _, err := h.decodeSpecs(req.Specs); err != nil {
+			return echo.NewHTTPError(400, fmt.Errorf("decode specs: %w", err))
+		}
+		value = req.Specs
+	case req.URL != "":
+		if _, err := url.Parse(req.URL); err != nil {
+			return echo.NewHTTPError(400, "url is invalid")
+		}
+		value = req.URL
+	default:
+		return echo.NewHTTPError(400, "specs or url is required")
+	}
+	return c.JSON(200, signResponse{Sig: h.signer.Sign(value)})
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/egor3f/rssalchemy/internal/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestSignature(t *testing.T) {
+	signer, err := signing.New([]string{"k1:0123456789abcdef"})
+	require.NoError(t, err)
+	e := newTestServer(t, Config{Auth: teamAuth, Signer: signer})
+
+	pageURL := "https://example.com/news"
+	screenshot := "/api/v1/screenshot?url=" + url.QueryEscape(pageURL)
+	key := map[string]string{"X-Api-Key": "team-secret"}
+
+	rec := doRequest(e, http.MethodPost, "/api/v1/sign", `{"url": "`+pageURL+`"}`, nil)
+	assert.Equal(t, 401, rec.Code)
+	rec = doRequest(e, http.MethodPost, "/api/v1/sign", `{"specs": "invalid"}`, key)
+	assert.Equal(t, 400, rec.Code)
+	rec = doRequest(e, http.MethodPost, "/api/v1/sign", `{"url": "`+pageURL+`"}`, key)
+	require.Equal(t, 200, rec.Code, rec.Body.String())
+	var signed signResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &signed))
+
+	tests := []struct {
+		name    string
+		target  string
+		headers map[string]string
+		// nop queue fails every task, so passed check results in 500
+		status int
+	}{
+		{"unsigned", screenshot, nil, 403},
+		{"signed", screenshot + "&sig=" + signed.Sig, nil, 500},
+		{"tampered", "/api/v1/screenshot?url=" + url.QueryEscape(pageURL+"/admin") + "&sig=" + signed.Sig, nil, 403},
+		{"api key instead of signature", screenshot, key, 500},
+		{"unsigned render", "/api/v1/render/1:AAAA", nil, 403},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(e, http.MethodGet, tt.target, "", tt.headers)
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestPreviewRequiresKeyWithSigning(t *testing.T) {
+	signer, err := signing.New([]string{"k1:0123456789abcdef"})
+	require.NoError(t, err)
+	e := newTestServer(t, Config{WorkQueue: &feedQueue{}, Auth: teamAuth, Signer: signer})
+	body, err := json.Marshal(testSpecs("https://example.com/"))
+	require.NoError(t, err)
+
+	rec := doRequest(e, http.MethodPost, "/api/v1/preview", string(body), nil)
+	assert.Equal(t, 401, rec.Code, rec.Body.String())
+	// feed queue result is not a preview, but it passes the check
+	rec = doRequest(e, http.MethodPost, "/api/v1/preview", string(body), map[string]string{"X-Api-Key": "team-secret"})
+	assert.Equal(t, 200, rec.Code, rec.Body.String())
+}
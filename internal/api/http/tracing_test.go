@@ -0,0 +1,44 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+	"net/http"
+	"testing"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	e := echo.New()
+	e.Use(TracingMiddleware)
+	e.GET("/test/render/:specs", func(c echo.Context) error {
+		_, span := tracer.Start(c.Request().Context(), "handler")
+		span.End()
+		return echo.NewHTTPError(502, "upstream failed")
+	})
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	doRequest(e, http.MethodGet, "/test/render/abc", "", map[string]string{
+		"traceparent": "00-" + traceID + "-00f067aa0ba902b7-01",
+	})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	handler, server := spans[0], spans[1]
+	assert.Equal(t, "GET /test/render/:specs", server.Name())
+	assert.Equal(t, traceID, server.SpanContext().TraceID().String())
+	assert.Equal(t, server.SpanContext().SpanID(), handler.Parent().SpanID())
+	assert.Contains(t, server.Attributes(), attribute.Int("http.response.status_code", 502))
+	assert.Equal(t, "status 502", server.Status().Description)
+}
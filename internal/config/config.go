@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"reflect"
 	"slices"
+	"strings"
 )
 
 type Config struct {
@@ -35,6 +36,34 @@ type Config struct {
 	// IP ranges of reverse proxies for correct real ip detection (cidr format, sep. by comma)
 	TrustedIpRanges []string `env:"TRUSTED_IP_RANGES" env-default:"" validate:"omitempty,dive,cidr"`
 	RealIpHeader    string   `env:"REAL_IP_HEADER" env-default:"" validate:"omitempty"`
+	// Target hosts policy: domains (example.com), wildcards (*.example.com), ips and cidrs (sep. by comma).
+	// If AllowedHosts is set, other hosts are rejected. Reserved addresses are always rejected.
+	AllowedHosts []string `env:"ALLOWED_HOSTS" env-default:"" validate:"omitempty,dive,min=1"`
+	DeniedHosts  []string `env:"DENIED_HOSTS" env-default:"" validate:"omitempty,dive,min=1"`
+	// Static api keys in format name:key (sep. by comma). More keys can be created via admin api
+	ApiKeys []string `env:"API_KEYS" env-default:"" validate:"omitempty,dive,named_secret"`
+	// If true, requests without api key are rejected, otherwise they are rate limited by ip
+	ApiKeyRequired bool `env:"API_KEY_REQUIRED"`
+	// Key for admin endpoints, admin api is available only to keys created with admin flag if empty
+	AdminApiKey string `env:"ADMIN_API_KEY" env-default:"" validate:"omitempty,min=16"`
+	// Default rate limit and daily quota of api keys (quota 0 - unlimited).
+	// Same meaning as TaskRateLimitEvery and TaskRateLimitBurst.
+	ApiKeyRateLimitEvery float64 `env:"API_KEY_RATE_LIMIT_EVERY" env-default:"10" validate:"number,gt=0"`
+	ApiKeyRateLimitBurst int     `env:"API_KEY_RATE_LIMIT_BURST" env-default:"30" validate:"number,gte=0"`
+	ApiKeyDailyQuota     int     `env:"API_KEY_DAILY_QUOTA" env-default:"0" validate:"number,gte=0"`
+	// Keys for signing feed urls in format id:secret (sep. by comma). If set, unsigned urls are rejected.
+	// The first key signs, others are only accepted (for rotation).
+	SpecsSigningKeys []string `env:"SPECS_SIGNING_KEYS" env-default:"" validate:"omitempty,dive,named_secret"`
+	// Address of worker http endpoint (/metrics, /healthz, /readyz), disabled if empty.
+	WorkerMetricsAddress string `env:"WORKER_METRICS_ADDRESS" env-default:"0.0.0.0:9100" validate:"omitempty,hostname_port"`
+	// Address of webserver /metrics endpoint, disabled if empty. Health checks are served on WebserverAddress.
+	WebserverMetricsAddress string `env:"WEBSERVER_METRICS_ADDRESS" env-default:"0.0.0.0:9101" validate:"omitempty,hostname_port"`
+	// OTLP/HTTP endpoint for traces (like http://jaeger:4318), tracing is disabled if empty
+	TracingEndpoint string `env:"TRACING_ENDPOINT" env-default:"" validate:"omitempty,url"`
+	// Fraction of sampled traces, 0..1
+	TracingSampleRatio float64 `env:"TRACING_SAMPLE_RATIO" env-default:"1" validate:"gte=0,lte=1"`
+	// Directory with additional presets (yaml or json, one per file), they replace builtin ones with the same name
+	PresetsDir string `env:"PRESETS_DIR" env-default:"" validate:"omitempty,dir"`
 }
 
 func Read() (Config, error) {
@@ -47,9 +76,16 @@ func Read() (Config, error) {
 	if err := validate.RegisterValidation("proxy", validateProxy); err != nil {
 		panic(fmt.Errorf("register validation: %w", err))
 	}
+	if err := validate.RegisterValidation("named_secret", validateNamedSecret); err != nil {
+		panic(fmt.Errorf("register validation: %w", err))
+	}
 	err = validate.Struct(cfg)
 	if err == nil {
-		fmt.Printf("Config: %+v\n", cfg)
+		printable := cfg
+		printable.ApiKeys = nil
+		printable.AdminApiKey = ""
+		printable.SpecsSigningKeys = nil
+		fmt.Printf("Config: %+v\n", printable)
 	}
 	return cfg, err
 }
@@ -62,3 +98,12 @@ func validateProxy(fl validator.FieldLevel) bool {
 	pUrl, err := url.Parse(fl.Field().String())
 	return err == nil && slices.Contains(validSchemes, pUrl.Scheme) && pUrl.Opaque == "" && pUrl.Path == ""
 }
+
+// validateNamedSecret checks name:secret entry
+func validateNamedSecret(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	name, key, found := strings.Cut(fl.Field().String(), ":")
+	return found && len(name) > 0 && len(key) >= 16
+}
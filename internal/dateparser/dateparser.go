@@ -3,24 +3,99 @@ package dateparser
 import (
 	"fmt"
 	godateparser "github.com/markusmobius/go-dateparser"
+	"strconv"
 	"strings"
 	"time"
+	_ "time/tzdata" // timezones in specs must work in minimal containers
 )
 
+const (
+	FormatUnix   = "unix"
+	FormatUnixMs = "unix_ms"
+)
+
+// Options are optional hints from task which help to parse ambiguous or non-standard dates
+type Options struct {
+	// Formats are tried before heuristics. Each format is Go layout, strftime format (if it contains %),
+	// FormatUnix or FormatUnixMs
+	Formats   []string
+	Languages []string
+	Timezone  *time.Location
+	DayFirst  bool
+}
+
+// ParseOptions builds Options from spec fields: newline separated formats,
+// comma separated languages and IANA timezone name
+func ParseOptions(formats string, languages string, timezone string, dayFirst bool) (Options, error) {
+	opts := Options{DayFirst: dayFirst}
+	for _, format := range strings.Split(formats, "\n") {
+		format = strings.TrimRight(format, "\r")
+		if strings.TrimSpace(format) == "" {
+			continue
+		}
+		if format != FormatUnix && format != FormatUnixMs {
+			if _, err := Layout(format); err != nil {
+				return Options{}, err
+			}
+		}
+		opts.Formats = append(opts.Formats, format)
+	}
+	for _, lang := range strings.Split(languages, ",") {
+		if lang = strings.TrimSpace(lang); lang != "" {
+			// unknown languages are silently ignored by parser
+			if !godateparser.IsKnownLocale(lang) {
+				return Options{}, fmt.Errorf("unknown date language %q", lang)
+			}
+			opts.Languages = append(opts.Languages, lang)
+		}
+	}
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return Options{}, fmt.Errorf("timezone: %w", err)
+		}
+		opts.Timezone = loc
+	}
+	return opts, nil
+}
+
 type DateParser struct {
 	CurrentTimeFunc func() time.Time
 }
 
-func (d *DateParser) ParseDate(str string) (time.Time, error) {
+func (d *DateParser) ParseDate(str string, opts Options) (time.Time, error) {
 	str = strings.TrimSpace(str)
 
 	if len(str) == 0 {
 		return time.Time{}, fmt.Errorf("date string is empty")
 	}
 
-	dt, err := godateparser.Parse(&godateparser.Configuration{
-		CurrentTime: d.CurrentTimeFunc(),
-	}, str)
+	var layouts []string
+	for _, format := range opts.Formats {
+		switch format {
+		case FormatUnix, FormatUnixMs:
+			if t, err := parseUnix(str, format == FormatUnixMs); err == nil {
+				return t, nil
+			}
+		default:
+			layout, err := Layout(format)
+			if err != nil {
+				return time.Time{}, err
+			}
+			layouts = append(layouts, layout)
+		}
+	}
+
+	cfg := &godateparser.Configuration{
+		CurrentTime:     d.CurrentTimeFunc(),
+		Languages:       opts.Languages,
+		DefaultTimezone: opts.Timezone,
+	}
+	if opts.DayFirst {
+		cfg.DateOrder = godateparser.DMY
+	}
+
+	dt, err := godateparser.Parse(cfg, str, layouts...)
 	if err == nil {
 		return dt.Time, nil
 	}
@@ -28,7 +103,7 @@ func (d *DateParser) ParseDate(str string) (time.Time, error) {
 	parts := strings.Split(str, " ")
 	for len(parts) > 1 {
 		newStr := strings.Join(parts, " ")
-		dt, err = godateparser.Parse(nil, newStr)
+		dt, err = godateparser.Parse(cfg, newStr, layouts...)
 		if err == nil {
 			return dt.Time, err
 		}
@@ -37,3 +112,70 @@ func (d *DateParser) ParseDate(str string) (time.Time, error) {
 
 	return time.Time{}, err
 }
+
+func parseUnix(str string, millis bool) (time.Time, error) {
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if millis {
+		return time.UnixMilli(int64(f)).UTC(), nil
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*1e9)).UTC(), nil
+}
+
+var strftimeDirectives = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'e': "_2",
+	'j': "002",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'f': "000000",
+	'p': "PM",
+	'b': "Jan",
+	'h': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+	'z': "-0700",
+	'Z': "MST",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'R': "15:04",
+	'D': "01/02/06",
+	'%': "%",
+}
+
+// Layout converts format to Go time layout. Formats containing % are treated as strftime formats,
+// other formats are returned as is.
+func Layout(format string) (string, error) {
+	if format == FormatUnix || format == FormatUnixMs {
+		return "", fmt.Errorf("%s is not a layout", format)
+	}
+	if !strings.Contains(format, "%") {
+		return format, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.WriteByte(format[i])
+			continue
+		}
+		if i+1 >= len(format) {
+			return "", fmt.Errorf("format %q ends with %%", format)
+		}
+		i++
+		layout, ok := strftimeDirectives[format[i]]
+		if !ok {
+			return "", fmt.Errorf("unsupported directive %%%c in format %q", format[i], format)
+		}
+		b.WriteString(layout)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,103 @@
+package dateparser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDate(t *testing.T) {
+	d := &DateParser{CurrentTimeFunc: func() time.Time {
+		return time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC)
+	}}
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected time.Time
+	}{
+		{
+			name:     "heuristics",
+			input:    "2024-03-05 12:30",
+			expected: time.Date(2024, 3, 5, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "relative date keeps current time in fallback",
+			input:    "posted by someone 2 days ago",
+			expected: time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day first",
+			input:    "03/05/2024",
+			opts:     Options{DayFirst: true},
+			expected: time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "go layout",
+			input:    "05.03.24 12h30",
+			opts:     Options{Formats: []string{"02.01.06 15h04"}},
+			expected: time.Date(2024, 3, 5, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "strftime",
+			input:    "05/Mar/2024:12:30",
+			opts:     Options{Formats: []string{"%d/%b/%Y:%H:%M"}},
+			expected: time.Date(2024, 3, 5, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "timezone",
+			input:    "2024-03-05 12:30",
+			opts:     Options{Timezone: moscow},
+			expected: time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "language",
+			input:    "5 марта 2024",
+			opts:     Options{Languages: []string{"ru"}},
+			expected: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "unix",
+			input:    "1709641800",
+			opts:     Options{Formats: []string{FormatUnix}},
+			expected: time.Date(2024, 3, 5, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "unix ms",
+			input:    "1709641800123",
+			opts:     Options{Formats: []string{FormatUnixMs}},
+			expected: time.Date(2024, 3, 5, 12, 30, 0, 123000000, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.ParseDate(tt.input, tt.opts)
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(got), "expected %s, got %s", tt.expected, got)
+		})
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	opts, err := ParseOptions("%Y-%m-%d\n\nunix\n2006", " en, ru ", "Europe/Moscow", true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"%Y-%m-%d", "unix", "2006"}, opts.Formats)
+	assert.Equal(t, []string{"en", "ru"}, opts.Languages)
+	assert.Equal(t, "Europe/Moscow", opts.Timezone.String())
+	assert.True(t, opts.DayFirst)
+
+	_, err = ParseOptions("%Q", "", "", false)
+	assert.Error(t, err)
+	_, err = ParseOptions("", "", "Mars/Olympus", false)
+	assert.Error(t, err)
+	_, err = ParseOptions("", "en, xx", "", false)
+	assert.ErrorContains(t, err, `unknown date language "xx"`)
+	_, err = ParseOptions("", "english", "", false)
+	assert.Error(t, err)
+}
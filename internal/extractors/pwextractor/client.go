@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"net/http"
 	"strings"
 	"time"
@@ -76,7 +79,23 @@ func newFlareClient(baseURL string, maxTimeoutMs int) (*flareClient, error) {
 	}, nil
 }
 
-func (c *flareClient) do(ctx context.Context, req flareRequest) (*flareResponse, error) {
+// ErrFlareSolverr wraps errors of flaresolverr itself (not of target site)
+var ErrFlareSolverr = errors.New("flaresolverr")
+
+func (c *flareClient) do(ctx context.Context, req flareRequest) (resp *flareResponse, errRet error) {
+	ctx, span := tracer.Start(ctx, "flaresolverr "+req.Cmd)
+	span.SetAttributes(attribute.String("url.full", req.Url))
+	start := time.Now()
+	defer func() {
+		result := "ok"
+		if errRet != nil {
+			result = "error"
+			span.SetStatus(codes.Error, errRet.Error())
+		}
+		flareDuration.WithLabelValues(req.Cmd, result).Observe(time.Since(start).Seconds())
+		span.End()
+	}()
+
 	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("marshal flaresolverr request: %w", err)
@@ -87,26 +106,47 @@ func (c *flareClient) do(ctx context.Context, req flareRequest) (*flareResponse,
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(httpReq)
+	httpResp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("flaresolverr request failed: %w", err)
+		return nil, fmt.Errorf("%w request failed: %w", ErrFlareSolverr, err)
 	}
-	defer resp.Body.Close()
+	defer httpResp.Body.Close()
 
 	var decoded flareResponse
-	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
-		return nil, fmt.Errorf("decode flaresolverr response: %w", err)
+	if err := json.NewDecoder(httpResp.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("decode %w response: %w", ErrFlareSolverr, err)
 	}
 	if decoded.Status != "ok" {
 		msg := decoded.Message
 		if msg == "" {
 			msg = fmt.Sprintf("status %s", decoded.Status)
 		}
-		return nil, fmt.Errorf("flaresolverr error: %s", msg)
+		return nil, fmt.Errorf("%w error: %s", ErrFlareSolverr, msg)
 	}
 	return &decoded, nil
 }
 
+// health checks that flaresolverr is up. Old versions without /health are checked by sessions.list
+func (c *flareClient) health(ctx context.Context) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/health", nil)
+	if err != nil {
+		return fmt.Errorf("create health request: %w", err)
+	}
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%w request failed: %w", ErrFlareSolverr, err)
+	}
+	defer httpResp.Body.Close()
+	switch httpResp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		_, err := c.do(ctx, flareRequest{Cmd: "sessions.list"})
+		return err
+	}
+	return fmt.Errorf("%w health status %d", ErrFlareSolverr, httpResp.StatusCode)
+}
+
 func (c *flareClient) createSession(ctx context.Context, proxy *flareProxy) (string, error) {
 	req := flareRequest{
 		Cmd:   "sessions.create",
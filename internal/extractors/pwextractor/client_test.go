@@ -0,0 +1,51 @@
+package pwextractor
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFlareHealth(t *testing.T) {
+	tests := []struct {
+		name          string
+		healthStatus  int
+		sessionsReply string // response to sessions.list, empty if not expected
+		wantErr       bool
+	}{
+		{"health ok", 200, "", false},
+		{"health failed", 500, "", true},
+		{"old version, sessions ok", 404, `{"status":"ok","sessions":[]}`, false},
+		{"old version, sessions error", 404, `{"status":"error","message":"broken"}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/health":
+					w.WriteHeader(tt.healthStatus)
+				case "/v1":
+					require.NotEmpty(t, tt.sessionsReply, "unexpected flaresolverr command")
+					var req flareRequest
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+					assert.Equal(t, "sessions.list", req.Cmd)
+					_, _ = w.Write([]byte(tt.sessionsReply))
+				}
+			}))
+			defer srv.Close()
+
+			client, err := newFlareClient(srv.URL, 1000)
+			require.NoError(t, err)
+			err = client.health(context.Background())
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrFlareSolverr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
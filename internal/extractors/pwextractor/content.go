@@ -1,7 +1,10 @@
 package pwextractor
 
 import (
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/labstack/gommon/log"
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 	"strings"
 )
 
@@ -11,14 +14,45 @@ var allowedMarkupTags = map[string]bool{
 	"strong": true,
 }
 
-func extractContentFromSelector(root *html.Node, selector string, baseURL *urlParts) string {
-	node, err := firstNode(root, selector)
+// skippedTags are not shown, their text is code or styles
+var skippedTags = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"noscript": true,
+	"template": true,
+}
+
+// htmlFromSelector returns unsanitized html of the first selected node, it's sanitized after transforms
+func htmlFromSelector(root *html.Node, selector string, selectorType models.SelectorType) string {
+	node, err := firstNode(root, selector, selectorType)
 	if err != nil || node == nil {
 		return ""
 	}
-	return extractContent(node, baseURL)
+	var b strings.Builder
+	if err := html.Render(&b, node); err != nil {
+		log.Debugf("render content: %v", err)
+		return ""
+	}
+	return b.String()
 }
 
+// sanitizeContent keeps only text, images and allowedMarkupTags of html string, like content of html pages
+func sanitizeContent(htmlStr string, baseURL *urlParts) string {
+	if strings.TrimSpace(htmlStr) == "" {
+		return ""
+	}
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(htmlStr), body)
+	if err != nil {
+		return html.EscapeString(htmlStr)
+	}
+	for _, node := range nodes {
+		body.AppendChild(node)
+	}
+	return extractContent(body, baseURL)
+}
+
+// extractContent converts node to safe html: text is escaped, only images and allowedMarkupTags are kept
 func extractContent(root *html.Node, baseURL *urlParts) string {
 	var content strings.Builder
 	var paragraph strings.Builder
@@ -41,7 +75,7 @@ func extractContent(root *html.Node, baseURL *urlParts) string {
 			return
 		}
 		content.WriteString(`<img src="`)
-		content.WriteString(src)
+		content.WriteString(html.EscapeString(src))
 		content.WriteString(`"/>`)
 	}
 
@@ -50,6 +84,9 @@ func extractContent(root *html.Node, baseURL *urlParts) string {
 		switch node.Type {
 		case html.ElementNode:
 			tag := strings.ToLower(node.Data)
+			if skippedTags[tag] {
+				return
+			}
 			if tag == "img" {
 				finishParagraph()
 				addImage(nodeAttr(node, "src"))
@@ -69,13 +106,18 @@ func extractContent(root *html.Node, baseURL *urlParts) string {
 				paragraph.WriteString(">")
 			}
 		case html.TextNode:
-			if strings.TrimSpace(node.Data) != "" {
-				paragraph.WriteString(node.Data)
+			// whitespace is collapsed like browser does, words of adjacent nodes are separated by space
+			if text := strings.Join(strings.Fields(node.Data), " "); text != "" {
+				paragraph.WriteString(html.EscapeString(text))
 				paragraph.WriteString(" ")
 			}
 		}
 	}
 
+	if root.Type == html.TextNode {
+		// xpath selectors can select text or attribute directly
+		walk(root)
+	}
 	for child := root.FirstChild; child != nil; child = child.NextSibling {
 		walk(child)
 	}
@@ -3,16 +3,24 @@ package pwextractor
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"github.com/egor3f/rssalchemy/internal/dateparser"
 	"github.com/egor3f/rssalchemy/internal/limiter"
 	"github.com/egor3f/rssalchemy/internal/models"
 	"github.com/labstack/gommon/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"net"
+	"net/http"
 	"time"
 )
 
+var tracer = otel.Tracer("github.com/egor3f/rssalchemy/internal/extractors/pwextractor")
+
 type DateParser interface {
-	ParseDate(string) (time.Time, error)
+	ParseDate(string, dateparser.Options) (time.Time, error)
 }
 
 type CookieManager interface {
@@ -22,12 +30,14 @@ type CookieManager interface {
 
 type PwExtractor struct {
 	client        *flareClient
+	httpClient    *http.Client
 	dateParser    DateParser
 	cookieManager CookieManager
 	limiter       limiter.Limiter
 	proxy         *flareProxy
 	proxyHasAuth  bool
 	proxyIP       net.IP
+	hostPolicy    *hostPolicy
 	maxTimeoutMs  int
 	waitSeconds   int
 }
@@ -40,6 +50,9 @@ type Config struct {
 	FlareSolverrURL        string
 	FlareSolverrMaxTimeout int
 	FlareSolverrWait       int
+	// AllowedHosts and DeniedHosts are domains, wildcards (*.example.com), ips and cidrs
+	AllowedHosts []string
+	DeniedHosts  []string
 }
 
 const (
@@ -65,6 +78,11 @@ func New(cfg Config) (*PwExtractor, error) {
 		proxyIP = proxyIPs[0]
 	}
 
+	policy, err := newHostPolicy(cfg.AllowedHosts, cfg.DeniedHosts)
+	if err != nil {
+		return nil, fmt.Errorf("host policy: %w", err)
+	}
+
 	maxTimeoutMs := cfg.FlareSolverrMaxTimeout
 	if maxTimeoutMs <= 0 {
 		maxTimeoutMs = defaultMaxTimeoutMs
@@ -75,7 +93,7 @@ func New(cfg Config) (*PwExtractor, error) {
 		return nil, fmt.Errorf("create flaresolverr client: %w", err)
 	}
 
-	return &PwExtractor{
+	e := &PwExtractor{
 		client:        client,
 		dateParser:    cfg.DateParser,
 		cookieManager: cfg.CookieManager,
@@ -83,17 +101,28 @@ func New(cfg Config) (*PwExtractor, error) {
 		proxy:         proxy,
 		proxyHasAuth:  proxyHasAuth,
 		proxyIP:       proxyIP,
+		hostPolicy:    policy,
 		maxTimeoutMs:  maxTimeoutMs,
 		waitSeconds:   cfg.FlareSolverrWait,
-	}, nil
+	}
+	e.httpClient, err = newHTTPClient(cfg.Proxy, time.Duration(maxTimeoutMs)*time.Millisecond, e.checkHost, e.checkIPs)
+	if err != nil {
+		return nil, fmt.Errorf("create http client: %w", err)
+	}
+	return e, nil
+}
+
+// CheckFlareSolverr is a readiness check of flaresolverr
+func (e *PwExtractor) CheckFlareSolverr(ctx context.Context) error {
+	return e.client.health(ctx)
 }
 
 func (e *PwExtractor) Stop() error {
 	return nil
 }
 
-func (e *PwExtractor) Extract(task models.Task) (result *models.TaskResult, errRet error) {
-	solution, baseURL, err := e.fetchSolution(context.Background(), task, false)
+func (e *PwExtractor) Extract(ctx context.Context, task models.Task) (result *models.TaskResult, errRet error) {
+	solution, baseURL, err := e.fetchSolution(ctx, task, false)
 	if err != nil {
 		return nil, err
 	}
@@ -103,18 +132,25 @@ func (e *PwExtractor) Extract(task models.Task) (result *models.TaskResult, errR
 		dateParser: e.dateParser,
 		baseURL:    baseURL,
 	}
-	result, err = parser.parse(solution.Response)
+	result, err = parser.parse(ctx, solution.Response)
 	if err != nil {
 		return nil, fmt.Errorf("parse page: %w", err)
 	}
 	return result, nil
 }
 
-func (e *PwExtractor) Screenshot(task models.Task) (result *models.ScreenshotTaskResult, errRet error) {
-	solution, _, err := e.fetchSolution(context.Background(), task, true)
+// Screenshot returns png of the page. If task has post selector,
+// nodes matched by selectors are outlined with different colours.
+func (e *PwExtractor) Screenshot(ctx context.Context, task models.Task) (result *models.ScreenshotTaskResult, errRet error) {
+	solution, baseURL, err := e.fetchSolution(ctx, task, task.SelectorPost == "")
 	if err != nil {
 		return nil, err
 	}
+	if task.SelectorPost != "" {
+		if solution, err = e.screenshotOverlay(ctx, solution, task, baseURL); err != nil {
+			return nil, fmt.Errorf("screenshot with overlay: %w", err)
+		}
+	}
 	if solution.Screenshot == "" {
 		return nil, fmt.Errorf("empty screenshot payload")
 	}
@@ -126,27 +162,66 @@ func (e *PwExtractor) Screenshot(task models.Task) (result *models.ScreenshotTas
 	return result, nil
 }
 
-func (e *PwExtractor) fetchSolution(ctx context.Context, task models.Task, wantScreenshot bool) (*flareSolution, *urlParts, error) {
+func (e *PwExtractor) ExtractJSON(ctx context.Context, task models.Task) (result *models.TaskResult, errRet error) {
+	body, baseURL, err := e.fetchPlain(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := jsonParser{
+		task:       task,
+		dateParser: e.dateParser,
+		baseURL:    baseURL,
+	}
+	result, err = parser.parse(ctx, body)
+	if err != nil {
+		return nil, fmt.Errorf("parse json: %w", err)
+	}
+	return result, nil
+}
+
+// checkTask applies per-domain rate limit and host restrictions before any outgoing request
+func (e *PwExtractor) checkTask(ctx context.Context, task models.Task) error {
 	baseDomain, _, err := parseBaseDomain(task.URL)
 	if err != nil {
-		return nil, nil, fmt.Errorf("parse base domain: %w", err)
+		return fmt.Errorf("parse base domain: %w", err)
 	}
 
+	if err := e.waitLimiter(ctx, baseDomain); err != nil {
+		return err
+	}
+
+	if err := e.checkHost(task.URL); err != nil {
+		return fmt.Errorf("check host: %w", err)
+	}
+	return nil
+}
+
+func (e *PwExtractor) waitLimiter(ctx context.Context, baseDomain string) error {
+	ctx, span := tracer.Start(ctx, "limiter wait")
+	span.SetAttributes(attribute.String("rssalchemy.domain", baseDomain))
+	defer span.End()
+
 	waitFor, err := e.limiter.Limit(ctx, baseDomain)
+	if errors.Is(err, limiter.ErrLimitReached) {
+		limiterRejections.Inc()
+	}
 	if err != nil {
-		return nil, nil, fmt.Errorf("bydomain limiter: %w", err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("bydomain limiter: %w", err)
 	}
+	limiterWait.Observe(waitFor.Seconds())
+	span.SetAttributes(attribute.Float64("rssalchemy.wait_seconds", waitFor.Seconds()))
 	if waitFor > 0 {
 		log.Infof("Bydomain limiter domain=%s wait=%v", baseDomain, waitFor)
 		time.Sleep(waitFor)
 	}
+	return nil
+}
 
-	allowHost, err := e.allowHost(task.URL)
-	if err != nil {
-		return nil, nil, fmt.Errorf("allow host: %w", err)
-	}
-	if !allowHost {
-		return nil, nil, fmt.Errorf("blocked host: %s", task.URL)
+func (e *PwExtractor) fetchSolution(ctx context.Context, task models.Task, wantScreenshot bool) (*flareSolution, *urlParts, error) {
+	if err := e.checkTask(ctx, task); err != nil {
+		return nil, nil, err
 	}
 
 	cookieStr, cookies := e.extractCookies(task.Headers, task.URL)
@@ -161,24 +236,8 @@ func (e *PwExtractor) fetchSolution(ctx context.Context, task models.Task, wantS
 	if len(cookies) > 0 {
 		req.Cookies = toFlareCookies(cookies)
 	}
-	if e.proxy != nil {
-		if e.proxyHasAuth {
-			session, err := e.client.createSession(ctx, e.proxy)
-			if err != nil {
-				return nil, nil, fmt.Errorf("create session: %w", err)
-			}
-			defer func() {
-				if err := e.client.destroySession(ctx, session); err != nil {
-					log.Warnf("destroy session failed: %v", err)
-				}
-			}()
-			req.Session = session
-		} else {
-			req.Proxy = e.proxy
-		}
-	}
 
-	resp, err := e.client.do(ctx, req)
+	resp, err := e.doFlareRequest(ctx, req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -187,12 +246,17 @@ func (e *PwExtractor) fetchSolution(ctx context.Context, task models.Task, wantS
 		return nil, nil, fmt.Errorf("empty flaresolverr solution")
 	}
 	if resp.Solution.Url != "" {
-		allowHost, err := e.allowHost(resp.Solution.Url)
-		if err != nil {
-			return nil, nil, fmt.Errorf("allow host: %w", err)
+		if err := e.checkHost(resp.Solution.Url); err != nil {
+			return nil, nil, fmt.Errorf("check final url: %w", err)
+		}
+	}
+	// browser resolved hosts itself, so page must not reach client if they are rebound to reserved addresses
+	for _, u := range []string{task.URL, resp.Solution.Url} {
+		if u == "" {
+			continue
 		}
-		if !allowHost {
-			return nil, nil, fmt.Errorf("blocked host: %s", resp.Solution.Url)
+		if err := e.verifyHost(ctx, u); err != nil {
+			return nil, nil, fmt.Errorf("verify host: %w", err)
 		}
 	}
 
@@ -206,14 +270,33 @@ func (e *PwExtractor) fetchSolution(ctx context.Context, task models.Task, wantS
 		for _, cook := range resp.Solution.Cookies {
 			newCookies = append(newCookies, [2]string{cook.Name, cook.Value})
 		}
-		if err := e.cookieManager.UpdateCookies(task.URL, cookieStr, newCookies); err != nil {
-			log.Errorf("cookie manager update: %v", err)
-		}
+		e.updateCookies(task.URL, cookieStr, newCookies)
 	}
 
 	return resp.Solution, baseURL, nil
 }
 
+// doFlareRequest sends request to flaresolverr through configured proxy
+func (e *PwExtractor) doFlareRequest(ctx context.Context, req flareRequest) (*flareResponse, error) {
+	if e.proxy != nil {
+		if e.proxyHasAuth {
+			session, err := e.client.createSession(ctx, e.proxy)
+			if err != nil {
+				return nil, fmt.Errorf("create session: %w", err)
+			}
+			defer func() {
+				if err := e.client.destroySession(ctx, session); err != nil {
+					log.Warnf("destroy session failed: %v", err)
+				}
+			}()
+			req.Session = session
+		} else {
+			req.Proxy = e.proxy
+		}
+	}
+	return e.client.do(ctx, req)
+}
+
 func (e *PwExtractor) extractCookies(headers map[string]string, taskURL string) (string, [][2]string) {
 	cookieStr := ""
 	cookies := make([][2]string, 0)
@@ -232,3 +315,9 @@ func (e *PwExtractor) extractCookies(headers map[string]string, taskURL string)
 	}
 	return cookieStr, cookies
 }
+
+func (e *PwExtractor) updateCookies(taskURL string, cookieStr string, cookies [][2]string) {
+	if err := e.cookieManager.UpdateCookies(taskURL, cookieStr, cookies); err != nil {
+		log.Errorf("cookie manager update: %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package pwextractor
+
+import (
+	"context"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/labstack/gommon/log"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	plainUserAgent  = "Mozilla/5.0 (compatible; RSSAlchemy/1.0)"
+	maxPlainBodyLen = 10 * 1024 * 1024
+	maxRedirects    = 10
+)
+
+// newHTTPClient makes client which checks every redirect hop. Without proxy, connections are pinned
+// to checked addresses; with proxy, target is resolved by proxy, so only our resolution is checked.
+func newHTTPClient(
+	proxy string,
+	timeout time.Duration,
+	checkHost func(rawUrl string) error,
+	checkIPs func(host string, ips []net.IP) error,
+) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	if strings.TrimSpace(proxy) == "" {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		transport.DialContext = pinnedDialContext(dialer, checkIPs)
+	} else {
+		proxyUrl, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("parse proxy: %w", err)
+		}
+		if proxyUrl.Scheme == "socks" {
+			proxyUrl.Scheme = "socks5"
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			if err := checkHost(req.URL.String()); err != nil {
+				return fmt.Errorf("redirect: %w", err)
+			}
+			return nil
+		},
+	}, nil
+}
+
+// pinnedDialContext resolves host, checks all addresses and connects only to checked ones,
+// so dns answer can't change between check and connection (dns rebinding)
+func pinnedDialContext(
+	dialer *net.Dialer,
+	checkIPs func(host string, ips []net.IP) error,
+) func(ctx context.Context, network string, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network string, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+		if err != nil {
+			return nil, fmt.Errorf("lookup ip: %w", err)
+		}
+		if err := checkIPs(host, ips); err != nil {
+			return nil, err
+		}
+		var lastErr error
+		for _, ip := range ips {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		return nil, lastErr
+	}
+}
+
+// fetchPlain downloads task url without browser rendering (used for json api sources)
+// and returns response body and final url after redirects
+func (e *PwExtractor) fetchPlain(ctx context.Context, task models.Task) ([]byte, *urlParts, error) {
+	if err := e.checkTask(ctx, task); err != nil {
+		return nil, nil, err
+	}
+
+	cookieStr, cookies := e.extractCookies(task.Headers, task.URL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, task.URL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", plainUserAgent)
+	if v, ok := task.Headers["Accept-Language"]; ok {
+		req.Header.Set("Accept-Language", v)
+	}
+	for _, cook := range cookies {
+		req.AddCookie(&http.Cookie{Name: cook[0], Value: cook[1]})
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+
+	// every redirect hop is already checked by client
+	finalURL := resp.Request.URL
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPlainBodyLen))
+	if err != nil {
+		return nil, nil, fmt.Errorf("read body: %w", err)
+	}
+	log.Debugf("Plain fetch url=%s status=%d len=%d", finalURL, resp.StatusCode, len(body))
+
+	if len(cookies) > 0 {
+		e.updateCookies(task.URL, cookieStr, mergeCookies(cookies, resp.Cookies()))
+	}
+
+	return body, finalURL, nil
+}
+
+// mergeCookies overrides sent cookies with values from Set-Cookie response headers
+func mergeCookies(sent [][2]string, received []*http.Cookie) [][2]string {
+	merged := make([][2]string, 0, len(sent)+len(received))
+	index := make(map[string]int, len(sent))
+	for _, cook := range sent {
+		index[cook[0]] = len(merged)
+		merged = append(merged, cook)
+	}
+	for _, cook := range received {
+		if i, ok := index[cook.Name]; ok {
+			merged[i][1] = cook.Value
+			continue
+		}
+		index[cook.Name] = len(merged)
+		merged = append(merged, [2]string{cook.Name, cook.Value})
+	}
+	return merged
+}
@@ -0,0 +1,67 @@
+package pwextractor
+
+import (
+	"context"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/dateparser"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"time"
+)
+
+// Fixtures are recorded pages of presets with golden results, they are replayed by tests
+// to catch parser changes which break known presets. Recorded by `extractor -record <dir>`.
+
+// FixtureTime is current time for relative dates ("2 hours ago") of fixtures, so golden results don't get stale
+var FixtureTime = time.Date(2025, 01, 10, 10, 00, 00, 00, time.UTC)
+
+// FixtureFiles returns file names of recorded page and golden result of preset fixture
+func FixtureFiles(name string, task models.Task) (page string, golden string) {
+	if task.TaskType == models.TaskTypeExtractJSON {
+		return name + ".page.json", name + ".golden.json"
+	}
+	return name + ".page.html", name + ".golden.json"
+}
+
+// FetchFixturePage returns page exactly as it's passed to parser by Extract or ExtractJSON
+func (e *PwExtractor) FetchFixturePage(ctx context.Context, task models.Task) ([]byte, error) {
+	if task.TaskType == models.TaskTypeExtractJSON {
+		body, _, err := e.fetchPlain(ctx, task)
+		return body, err
+	}
+	solution, _, err := e.fetchSolution(ctx, task, false)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(solution.Response), nil
+}
+
+// ParseFixture parses recorded page like Extract or ExtractJSON with dates relative to FixtureTime, in UTC.
+// Links are resolved against task url, not against final url after redirects.
+func ParseFixture(ctx context.Context, task models.Task, page []byte) (*models.TaskResult, error) {
+	dp := &dateparser.DateParser{
+		CurrentTimeFunc: func() time.Time {
+			return FixtureTime
+		},
+	}
+	var result *models.TaskResult
+	var err error
+	switch task.TaskType {
+	case models.TaskTypeExtract:
+		parser := htmlParser{task: task, dateParser: dp, baseURL: parseURL(task.URL)}
+		result, err = parser.parse(ctx, string(page))
+	case models.TaskTypeExtractJSON:
+		parser := jsonParser{task: task, dateParser: dp, baseURL: parseURL(task.URL)}
+		result, err = parser.parse(ctx, page)
+	default:
+		return nil, fmt.Errorf("invalid fixture task type: %s", task.TaskType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// timestamps are parsed in local zone, golden results must not depend on it
+	for i := range result.Items {
+		result.Items[i].Created = result.Items[i].Created.UTC()
+		result.Items[i].Updated = result.Items[i].Updated.UTC()
+	}
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package pwextractor
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/egor3f/rssalchemy/internal/presets"
+	"github.com/egor3f/rssalchemy/internal/specs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden results of preset fixtures from recorded pages")
+
+const fixturesDir = "testdata/presets"
+
+// TestPresetFixtures replays recorded page of every builtin preset through parser and compares with golden result.
+// Every builtin preset must have a page, so new preset can't be added without fixture.
+// After intended parser change run `go test ./internal/extractors/pwextractor -run TestPresetFixtures -update`
+// and review the diff; to refresh pages use `extractor -preset <name> -record <dir>`.
+func TestPresetFixtures(t *testing.T) {
+	registry, err := presets.New("")
+	require.NoError(t, err)
+
+	for _, preset := range registry.Search("", "") {
+		t.Run(preset.Name, func(t *testing.T) {
+			presetSpecs, err := preset.Specs()
+			require.NoError(t, err)
+			task, err := specs.ToTask(presetSpecs)
+			require.NoError(t, err)
+
+			pageFile, goldenFile := FixtureFiles(preset.Name, task)
+			page, err := os.ReadFile(filepath.Join(fixturesDir, pageFile))
+			require.NoError(t, err, "every builtin preset needs a page, record it with extractor -preset %s -record internal/extractors/pwextractor/%s", preset.Name, fixturesDir)
+
+			result, err := ParseFixture(context.Background(), task, page)
+			require.NoError(t, err)
+			actual, err := json.MarshalIndent(result, "", "\t")
+			require.NoError(t, err)
+
+			goldenPath := filepath.Join(fixturesDir, goldenFile)
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(goldenPath, append(actual, '\n'), 0644))
+			}
+			golden, err := os.ReadFile(goldenPath)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(golden), string(actual))
+		})
+	}
+}
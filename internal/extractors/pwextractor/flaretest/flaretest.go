@@ -0,0 +1,354 @@
+// Package flaretest is a fake FlareSolverr for tests and offline development.
+// It implements subset of v1 api used by pwextractor: request.get, sessions.create, sessions.destroy,
+// sessions.list and /health. Pages are served from memory or files instead of real browser,
+// base64 html data urls are rendered as is.
+package flaretest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const UserAgent = "Mozilla/5.0 (X11; Linux x86_64) flaretest"
+
+// Screenshot is png returned when screenshot is requested
+var Screenshot = func() []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}()
+
+type Cookie struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type Proxy struct {
+	Url      string `json:"url"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// Page is response of target site
+type Page struct {
+	// Status of target page, 200 if zero
+	Status int
+	HTML   string
+	// FinalURL is url after redirects, request url if empty
+	FinalURL string
+	// Cookies set by page, they are added to cookies of request and session
+	Cookies []Cookie
+	// Delay is time of solving challenge. If it's longer than maxTimeout of request, timeout error is returned.
+	Delay time.Duration
+	// Error is returned by flaresolverr instead of solution, like "Error solving the challenge"
+	Error string
+}
+
+// Request is command received by fake
+type Request struct {
+	Cmd              string   `json:"cmd"`
+	Url              string   `json:"url,omitempty"`
+	Cookies          []Cookie `json:"cookies,omitempty"`
+	MaxTimeout       int      `json:"maxTimeout,omitempty"`
+	Proxy            *Proxy   `json:"proxy,omitempty"`
+	Session          string   `json:"session,omitempty"`
+	ReturnScreenshot bool     `json:"returnScreenshot,omitempty"`
+	WaitInSeconds    int      `json:"waitInSeconds,omitempty"`
+}
+
+type solution struct {
+	Url        string            `json:"url"`
+	Status     int               `json:"status"`
+	Headers    map[string]string `json:"headers"`
+	Response   string            `json:"response"`
+	Cookies    []Cookie          `json:"cookies"`
+	UserAgent  string            `json:"userAgent"`
+	Screenshot string            `json:"screenshot,omitempty"`
+}
+
+type response struct {
+	Status   string    `json:"status"`
+	Message  string    `json:"message"`
+	Session  string    `json:"session,omitempty"`
+	Sessions []string  `json:"sessions,omitempty"`
+	Solution *solution `json:"solution,omitempty"`
+}
+
+type session struct {
+	proxy   *Proxy
+	cookies []Cookie
+}
+
+// FlareSolverr is http.Handler of fake, safe for concurrent use
+type FlareSolverr struct {
+	mu         sync.Mutex
+	pages      map[string]Page
+	sessions   map[string]*session
+	sessionSeq int
+	requests   []Request
+	unhealthy  bool
+}
+
+func New() *FlareSolverr {
+	return &FlareSolverr{
+		pages:    make(map[string]Page),
+		sessions: make(map[string]*session),
+	}
+}
+
+// Server is fake running on httptest server
+type Server struct {
+	*httptest.Server
+	*FlareSolverr
+}
+
+// NewServer starts fake on local address, its URL is FlareSolverr url for pwextractor
+func NewServer() *Server {
+	f := New()
+	return &Server{Server: httptest.NewServer(f), FlareSolverr: f}
+}
+
+// SetPage sets page served for url. Unknown urls are not resolved, like in real browser.
+func (f *FlareSolverr) SetPage(url string, page Page) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pages[url] = page
+}
+
+// SetPageFile sets page with html from file
+func (f *FlareSolverr) SetPageFile(url string, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read page: %w", err)
+	}
+	f.SetPage(url, Page{HTML: string(data)})
+	return nil
+}
+
+// SetHealthy switches /health status
+func (f *FlareSolverr) SetHealthy(healthy bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.unhealthy = !healthy
+}
+
+// Requests returns received commands in order
+func (f *FlareSolverr) Requests() []Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.requests)
+}
+
+// Sessions returns ids of sessions which were created and not destroyed
+func (f *FlareSolverr) Sessions() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sessionIDs()
+}
+
+// SessionProxy returns proxy of session, nil if session doesn't exist or has no proxy
+func (f *FlareSolverr) SessionProxy(id string) *Proxy {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok := f.sessions[id]; ok {
+		return s.proxy
+	}
+	return nil
+}
+
+func (f *FlareSolverr) sessionIDs() []string {
+	ids := make([]string, 0, len(f.sessions))
+	for id := range f.sessions {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func (f *FlareSolverr) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/health":
+		f.mu.Lock()
+		unhealthy := f.unhealthy
+		f.mu.Unlock()
+		if unhealthy {
+			writeJSON(w, http.StatusInternalServerError, response{Status: "error", Message: "unhealthy"})
+			return
+		}
+		writeJSON(w, http.StatusOK, response{Status: "ok"})
+	case r.Method == http.MethodPost && r.URL.Path == "/v1":
+		var req Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, fmt.Sprintf("Request parameter 'cmd' is mandatory: %v", err))
+			return
+		}
+		f.mu.Lock()
+		f.requests = append(f.requests, req)
+		f.mu.Unlock()
+		f.handleCommand(w, r, req)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *FlareSolverr) handleCommand(w http.ResponseWriter, r *http.Request, req Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch req.Cmd {
+	case "sessions.create":
+		id := req.Session
+		if id == "" {
+			f.sessionSeq++
+			id = fmt.Sprintf("session-%d", f.sessionSeq)
+		}
+		if _, ok := f.sessions[id]; ok {
+			writeJSON(w, http.StatusOK, response{Status: "ok", Message: "Session already exists.", Session: id})
+			return
+		}
+		f.sessions[id] = &session{proxy: req.Proxy}
+		writeJSON(w, http.StatusOK, response{Status: "ok", Message: "Session created successfully.", Session: id})
+	case "sessions.destroy":
+		if _, ok := f.sessions[req.Session]; !ok {
+			writeError(w, "The session doesn't exist.")
+			return
+		}
+		delete(f.sessions, req.Session)
+		writeJSON(w, http.StatusOK, response{Status: "ok", Message: "The session has been removed."})
+	case "sessions.list":
+		writeJSON(w, http.StatusOK, response{Status: "ok", Sessions: f.sessionIDs()})
+	case "request.get":
+		var sess *session
+		if req.Session != "" {
+			var ok bool
+			if sess, ok = f.sessions[req.Session]; !ok {
+				writeError(w, "This session does not exist.")
+				return
+			}
+		}
+		page, ok := f.pages[req.Url]
+		if !ok {
+			// browser renders data urls itself
+			page, ok = dataURLPage(req.Url)
+		}
+		if !ok {
+			writeError(w, fmt.Sprintf("Error solving the challenge. net::ERR_NAME_NOT_RESOLVED at %s", req.Url))
+			return
+		}
+		// don't block other requests while "solving"
+		f.mu.Unlock()
+		solved := wait(r, page.Delay, time.Duration(req.MaxTimeout)*time.Millisecond)
+		f.mu.Lock()
+		if !solved {
+			writeError(w, fmt.Sprintf("Error solving the challenge. Timeout after %v seconds.", float64(req.MaxTimeout)/1000))
+			return
+		}
+		if page.Error != "" {
+			writeError(w, page.Error)
+			return
+		}
+		writeJSON(w, http.StatusOK, response{
+			Status:   "ok",
+			Message:  "Challenge not detected!",
+			Solution: newSolution(req, page, sess),
+		})
+	default:
+		writeError(w, fmt.Sprintf("Request parameter 'cmd' = '%s' is invalid.", req.Cmd))
+	}
+}
+
+func newSolution(req Request, page Page, sess *session) *solution {
+	cookies := req.Cookies
+	if sess != nil {
+		cookies = mergeCookies(sess.cookies, cookies)
+	}
+	cookies = mergeCookies(cookies, page.Cookies)
+	if sess != nil {
+		sess.cookies = cookies
+	}
+
+	sol := &solution{
+		Url:       page.FinalURL,
+		Status:    page.Status,
+		Headers:   map[string]string{},
+		Response:  page.HTML,
+		Cookies:   cookies,
+		UserAgent: UserAgent,
+	}
+	if sol.Url == "" {
+		sol.Url = req.Url
+	}
+	if sol.Status == 0 {
+		sol.Status = http.StatusOK
+	}
+	if req.ReturnScreenshot {
+		sol.Screenshot = base64.StdEncoding.EncodeToString(Screenshot)
+	}
+	return sol
+}
+
+// mergeCookies returns base cookies replaced and extended by override ones
+func mergeCookies(base []Cookie, override []Cookie) []Cookie {
+	result := slices.Clone(base)
+	for _, cookie := range override {
+		i := slices.IndexFunc(result, func(c Cookie) bool { return c.Name == cookie.Name })
+		if i >= 0 {
+			result[i] = cookie
+		} else {
+			result = append(result, cookie)
+		}
+	}
+	return result
+}
+
+// wait returns false if delay exceeds max timeout (zero means no limit) or request is cancelled
+func wait(r *http.Request, delay time.Duration, maxTimeout time.Duration) bool {
+	timedOut := maxTimeout > 0 && delay > maxTimeout
+	if timedOut {
+		delay = maxTimeout
+	}
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return false
+		}
+	}
+	return !timedOut
+}
+
+func writeError(w http.ResponseWriter, message string) {
+	writeJSON(w, http.StatusInternalServerError, response{Status: "error", Message: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, resp response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// dataURLPage returns page of base64 encoded html data url
+func dataURLPage(rawURL string) (Page, bool) {
+	encoded, ok := strings.CutPrefix(rawURL, "data:text/html;base64,")
+	if !ok {
+		return Page{}, false
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return Page{}, false
+	}
+	return Page{HTML: string(data)}, true
+}
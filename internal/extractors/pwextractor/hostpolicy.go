@@ -0,0 +1,152 @@
+package pwextractor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/labstack/gommon/log"
+	"net"
+	"strings"
+)
+
+// HostRejectedError is returned when target url is rejected by host restrictions.
+// Its message is shown to client, so it must not contain internal details like resolved ips.
+type HostRejectedError struct {
+	Host   string
+	Reason string
+}
+
+func (e *HostRejectedError) Error() string {
+	return fmt.Sprintf("host %s is not allowed: %s", e.Host, e.Reason)
+}
+
+// TaskResultPayload marshals result of task for queue. Rejected host is stored as models.TaskError,
+// so webserver answers 403 without retrying the task. Other errors are returned.
+func TaskResultPayload(result any, err error) ([]byte, error) {
+	var rejected *HostRejectedError
+	if errors.As(err, &rejected) {
+		log.Warnf("task rejected: %v", err)
+		result = models.TaskError{Kind: models.TaskErrorRejected, Error: rejected.Error()}
+	} else if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal result: %w", err)
+	}
+	return payload, nil
+}
+
+// hostRule matches exact domain, subdomains of wildcard domain (*.example.com) or ip network
+type hostRule struct {
+	domain  string
+	suffix  string
+	network *net.IPNet
+}
+
+func parseHostRule(s string) (hostRule, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return hostRule{}, fmt.Errorf("empty host rule")
+	}
+	if strings.Contains(s, "/") {
+		_, network, err := net.ParseCIDR(s)
+		if err != nil {
+			return hostRule{}, fmt.Errorf("invalid cidr %s: %w", s, err)
+		}
+		return hostRule{network: network}, nil
+	}
+	if ip := net.ParseIP(s); ip != nil {
+		bits := 8 * len(ip.To16())
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 32
+		}
+		return hostRule{network: &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}}, nil
+	}
+	if suffix, found := strings.CutPrefix(s, "*."); found {
+		if suffix == "" || strings.Contains(suffix, "*") {
+			return hostRule{}, fmt.Errorf("invalid wildcard %s", s)
+		}
+		return hostRule{suffix: "." + suffix}, nil
+	}
+	if strings.Contains(s, "*") {
+		return hostRule{}, fmt.Errorf("wildcard is supported only as *. prefix: %s", s)
+	}
+	return hostRule{domain: s}, nil
+}
+
+func (r hostRule) matchHost(host string) bool {
+	switch {
+	case r.domain != "":
+		return host == r.domain
+	case r.suffix != "":
+		return strings.HasSuffix(host, r.suffix)
+	}
+	return false
+}
+
+func (r hostRule) matchIP(ip net.IP) bool {
+	return r.network != nil && r.network.Contains(ip)
+}
+
+// hostPolicy is operator defined allowlist and denylist of target hosts.
+// Denylist wins; if allowlist is not empty, host must match it by name, or all its ips must match it.
+type hostPolicy struct {
+	allow []hostRule
+	deny  []hostRule
+}
+
+func newHostPolicy(allow []string, deny []string) (*hostPolicy, error) {
+	p := &hostPolicy{}
+	for _, s := range allow {
+		rule, err := parseHostRule(s)
+		if err != nil {
+			return nil, fmt.Errorf("allowed hosts: %w", err)
+		}
+		p.allow = append(p.allow, rule)
+	}
+	for _, s := range deny {
+		rule, err := parseHostRule(s)
+		if err != nil {
+			return nil, fmt.Errorf("denied hosts: %w", err)
+		}
+		p.deny = append(p.deny, rule)
+	}
+	return p, nil
+}
+
+func (p *hostPolicy) check(host string, ips []net.IP) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, rule := range p.deny {
+		if rule.matchHost(host) {
+			return &HostRejectedError{Host: host, Reason: "denied by policy"}
+		}
+		for _, ip := range ips {
+			if rule.matchIP(ip) {
+				return &HostRejectedError{Host: host, Reason: "denied by policy"}
+			}
+		}
+	}
+	if len(p.allow) == 0 {
+		return nil
+	}
+	for _, rule := range p.allow {
+		if rule.matchHost(host) {
+			return nil
+		}
+	}
+	if len(ips) == 0 {
+		return &HostRejectedError{Host: host, Reason: "not in allowlist"}
+	}
+	for _, ip := range ips {
+		allowed := false
+		for _, rule := range p.allow {
+			allowed = allowed || rule.matchIP(ip)
+		}
+		if !allowed {
+			return &HostRejectedError{Host: host, Reason: "not in allowlist"}
+		}
+	}
+	return nil
+}
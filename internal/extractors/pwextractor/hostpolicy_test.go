@@ -0,0 +1,67 @@
+package pwextractor
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+)
+
+func TestHostPolicy(t *testing.T) {
+	ips := func(s ...string) []net.IP {
+		var result []net.IP
+		for _, ip := range s {
+			result = append(result, net.ParseIP(ip))
+		}
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		allow  []string
+		deny   []string
+		host   string
+		ips    []net.IP
+		reason string // empty if allowed
+	}{
+		{"empty policy", nil, nil, "example.com", ips("93.184.216.34"), ""},
+		{"denied domain", nil, []string{"example.com"}, "example.com", ips("93.184.216.34"), "denied by policy"},
+		{"denied domain case and dot", nil, []string{"Example.COM"}, "EXAMPLE.com.", nil, "denied by policy"},
+		{"exact domain doesn't match subdomain", nil, []string{"example.com"}, "www.example.com", nil, ""},
+		{"denied wildcard", nil, []string{"*.example.com"}, "a.b.example.com", nil, "denied by policy"},
+		{"wildcard doesn't match apex", nil, []string{"*.example.com"}, "example.com", nil, ""},
+		{"denied cidr", nil, []string{"93.184.0.0/16"}, "example.com", ips("1.1.1.1", "93.184.216.34"), "denied by policy"},
+		{"denied ip", nil, []string{"2001:db8::1"}, "example.com", ips("2001:db8::1"), "denied by policy"},
+		{"allowed domain", []string{"example.com"}, nil, "example.com", ips("93.184.216.34"), ""},
+		{"allowed wildcard", []string{"*.example.com"}, nil, "news.example.com", nil, ""},
+		{"not in allowlist", []string{"example.com"}, nil, "example.org", ips("93.184.216.34"), "not in allowlist"},
+		{"allowed cidr", []string{"93.184.0.0/16"}, nil, "example.org", ips("93.184.216.34"), ""},
+		{"partially allowed cidr", []string{"93.184.0.0/16"}, nil, "example.org", ips("93.184.216.34", "1.1.1.1"), "not in allowlist"},
+		{"deny wins", []string{"*.example.com"}, []string{"admin.example.com"}, "admin.example.com", nil, "denied by policy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := newHostPolicy(tt.allow, tt.deny)
+			require.NoError(t, err)
+			err = policy.check(tt.host, tt.ips)
+			if tt.reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var rejected *HostRejectedError
+			require.ErrorAs(t, err, &rejected)
+			assert.Equal(t, tt.reason, rejected.Reason)
+		})
+	}
+}
+
+func TestParseHostRule(t *testing.T) {
+	for _, s := range []string{"example.com", "*.example.com", "10.0.0.0/8", "192.0.2.1", "2001:db8::/32"} {
+		_, err := parseHostRule(s)
+		assert.NoError(t, err, s)
+	}
+	for _, s := range []string{"", "*.", "ex*mple.com", "*.*.example.com", "10.0.0.0/33"} {
+		_, err := parseHostRule(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,155 @@
+package pwextractor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/dateparser"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/transform"
+	"github.com/jmespath/go-jmespath"
+	"github.com/labstack/gommon/log"
+	"strconv"
+	"strings"
+)
+
+// jsonParser extracts posts from json api responses.
+// Task selectors are JMESPath expressions: post selector is evaluated against the whole document
+// and must return an array, other selectors are evaluated against every array element.
+type jsonParser struct {
+	task        models.Task
+	dateParser  DateParser
+	baseURL     *urlParts
+	transforms  fieldTransforms
+	dateOptions dateparser.Options
+	diag        *previewCollector
+}
+
+func (p *jsonParser) parse(ctx context.Context, body []byte) (result *models.TaskResult, err error) {
+	_, span := tracer.Start(ctx, "jsonParser.parse")
+	defer func() {
+		endParseSpan(span, result, err)
+	}()
+
+	if p.transforms, err = newFieldTransforms(p.task); err != nil {
+		return nil, err
+	}
+	if p.dateOptions, err = newDateOptions(p.task); err != nil {
+		return nil, err
+	}
+
+	var doc any
+	if err = json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+
+	result = &models.TaskResult{}
+	if p.baseURL != nil {
+		result.Title = p.baseURL.Hostname()
+	}
+
+	posts, err := jmespath.Search(p.task.SelectorPost, doc)
+	if err != nil {
+		return nil, fmt.Errorf("post selector: %w", err)
+	}
+	postList, ok := posts.([]any)
+	if !ok {
+		return nil, fmt.Errorf("post selector result is not an array")
+	}
+	if len(postList) == 0 {
+		return nil, fmt.Errorf("no posts in document")
+	}
+	log.Debugf("Posts count=%d", len(postList))
+	p.diag.page(result.Title, len(postList))
+
+	for _, post := range postList {
+		p.diag.startPost()
+		item, err := p.extractPost(post)
+		if err != nil {
+			log.Errorf("extract post fields: %v", err)
+			p.diag.skip(err.Error())
+			continue
+		}
+		if missing := missingRequiredFields(item); len(missing) > 0 {
+			log.Warnf("post has no required fields (%s), skip", strings.Join(missing, ", "))
+			p.diag.skip(fmt.Sprintf("missing required fields: %s", strings.Join(missing, ", ")))
+			continue
+		}
+		result.Items = append(result.Items, item)
+	}
+	if len(result.Items) == 0 {
+		return nil, fmt.Errorf("extract failed for all posts")
+	}
+	return result, nil
+}
+
+func (p *jsonParser) extractPost(post any) (models.FeedItem, error) {
+	var item models.FeedItem
+
+	raw := map[string]string{
+		transform.FieldTitle:       jsonValue(post, p.task.SelectorTitle),
+		transform.FieldLink:        jsonValue(post, p.task.SelectorLink),
+		transform.FieldDescription: jsonValue(post, p.task.SelectorDescription),
+		transform.FieldAuthor:      jsonValue(post, p.task.SelectorAuthor),
+		transform.FieldCreated:     jsonValue(post, p.task.SelectorCreated),
+		transform.FieldContent:     jsonValue(post, p.task.SelectorContent),
+		transform.FieldEnclosure:   jsonValue(post, p.task.SelectorEnclosure),
+	}
+	fields := p.transforms.apply(raw, p.baseURL)
+	p.diag.values(raw, fields)
+	for field, value := range raw {
+		if value != "" {
+			p.diag.selectorMatches(field, 1)
+		}
+	}
+
+	item.Title = fields[transform.FieldTitle]
+	log.Debugf("---- POST: %s ----", item.Title)
+
+	item.Link = absURL(fields[transform.FieldLink], p.baseURL)
+	item.Description = fields[transform.FieldDescription]
+	item.AuthorName = fields[transform.FieldAuthor]
+	item.Content = fields[transform.FieldContent]
+	item.Enclosure = absURL(fields[transform.FieldEnclosure], p.baseURL)
+
+	createdDateStr := fields[transform.FieldCreated]
+	log.Debugf("date=%s", createdDateStr)
+	createdDate, err := p.dateParser.ParseDate(createdDateStr, p.dateOptions)
+	p.diag.date(createdDate, err)
+	if err != nil {
+		log.Errorf("dateparser: %v", err)
+	} else {
+		item.Created = createdDate
+	}
+
+	return item, nil
+}
+
+// jsonValue evaluates JMESPath expression and converts scalar result to string.
+// Empty expression, missing value and evaluation errors give empty string.
+func jsonValue(data any, expr string) string {
+	if strings.TrimSpace(expr) == "" {
+		return ""
+	}
+	value, err := jmespath.Search(expr, data)
+	if err != nil {
+		log.Debugf("jmespath %s: %v", expr, err)
+		return ""
+	}
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(encoded)
+	}
+}
@@ -0,0 +1,218 @@
+package pwextractor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/egor3f/rssalchemy/internal/dateparser"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAPIURL = "http://93.184.215.14/api/posts"
+	// noFlareURL is never requested, plain fetch doesn't use flaresolverr
+	noFlareURL = "http://127.0.0.1:1"
+)
+
+func TestJsonValue(t *testing.T) {
+	var post any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"title": "  Hello  ",
+		"id": 42,
+		"score": 1.5,
+		"pinned": true,
+		"author": {"name": "bob"},
+		"tags": ["a", "b"],
+		"empty": null
+	}`), &post))
+
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{"string is trimmed", "title", "Hello"},
+		{"integer number", "id", "42"},
+		{"float number", "score", "1.5"},
+		{"bool", "pinned", "true"},
+		{"nested scalar", "author.name", "bob"},
+		{"object", "author", `{"name":"bob"}`},
+		{"array", "tags", `["a","b"]`},
+		{"null", "empty", ""},
+		{"missing", "nope", ""},
+		{"missing nested", "author.nope.deeper", ""},
+		{"empty expression", "", ""},
+		{"blank expression", "   ", ""},
+		{"invalid expression", "title[", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, jsonValue(post, tt.expr))
+		})
+	}
+}
+
+func TestJsonParser(t *testing.T) {
+	task := models.Task{
+		TaskType:        models.TaskTypeExtractJSON,
+		URL:             testAPIURL,
+		SelectorPost:    "data.posts",
+		SelectorTitle:   "title",
+		SelectorLink:    "url",
+		SelectorCreated: "created",
+		SelectorContent: "body",
+	}
+	tests := []struct {
+		name        string
+		body        string
+		selector    string
+		wantErr     string
+		wantTitles  []string
+		wantLink    string
+		wantContent string
+	}{
+		{
+			name: "ok",
+			body: `{"data": {"posts": [
+				{"title": "First", "url": "/p/1", "created": "2025-01-09 10:00", "body": "<b>bold</b> text"},
+				{"title": "Second", "url": "https://other.example.com/2", "created": "2025-01-08 10:00"}
+			]}}`,
+			wantTitles:  []string{"First", "Second"},
+			wantLink:    "http://93.184.215.14/p/1",
+			wantContent: "<p><b>bold </b>text</p>",
+		},
+		{
+			name: "content is sanitized",
+			body: `{"data": {"posts": [{"title": "First", "url": "/p/1", "created": "2025-01-09 10:00",
+				"body": "<script>alert(1)</script><p onclick=\"x()\">hi</p><img src=\"/i.png\" onerror=\"x()\">"}]}}`,
+			wantTitles:  []string{"First"},
+			wantLink:    "http://93.184.215.14/p/1",
+			wantContent: `<p>hi</p><img src="http://93.184.215.14/i.png"/>`,
+		},
+		{
+			name: "posts without required fields are skipped",
+			body: `{"data": {"posts": [
+				{"title": "No link", "created": "2025-01-09 10:00"},
+				{"title": "First", "url": "/p/1", "created": "2025-01-09 10:00"}
+			]}}`,
+			wantTitles: []string{"First"},
+			wantLink:   "http://93.184.215.14/p/1",
+		},
+		{name: "post selector is object", body: `{"data": {"posts": {"title": "First"}}}`, wantErr: "not an array"},
+		{name: "post selector is scalar", body: `{"data": {"posts": "First"}}`, wantErr: "not an array"},
+		{name: "post selector is missing", body: `{"data": {}}`, wantErr: "not an array"},
+		{name: "invalid post selector", body: `{"data": {"posts": []}}`, selector: "data.posts[", wantErr: "post selector"},
+		{name: "no posts", body: `{"data": {"posts": []}}`, wantErr: "no posts"},
+		{name: "all posts invalid", body: `{"data": {"posts": [{"title": "No link"}]}}`, wantErr: "extract failed"},
+		{name: "invalid json", body: `{"data": `, wantErr: "unmarshal json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := task
+			if tt.selector != "" {
+				task.SelectorPost = tt.selector
+			}
+			p := jsonParser{
+				task:       task,
+				dateParser: &dateparser.DateParser{CurrentTimeFunc: func() time.Time { return FixtureTime }},
+				baseURL:    parseURL(task.URL),
+			}
+			result, err := p.parse(context.Background(), []byte(tt.body))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "93.184.215.14", result.Title)
+			var titles []string
+			for _, item := range result.Items {
+				titles = append(titles, item.Title)
+				assert.False(t, item.Created.IsZero())
+			}
+			assert.Equal(t, tt.wantTitles, titles)
+			assert.Equal(t, tt.wantLink, result.Items[0].Link)
+			assert.Equal(t, tt.wantContent, result.Items[0].Content)
+		})
+	}
+}
+
+func TestFetchPlain(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		cookie     string
+		wantErr    string
+		wantCookie [][2]string
+	}{
+		{name: "ok", status: 200, body: `{"posts": []}`},
+		{
+			name: "cookies are sent and updated", status: 200, body: `{}`, cookie: "sid=old; lang=en",
+			wantCookie: [][2]string{{"sid", "new"}, {"lang", "en"}},
+		},
+		{name: "not found", status: 404, body: `{}`, wantErr: "unexpected status: 404"},
+		{name: "server error", status: 502, body: `{}`, wantErr: "unexpected status: 502"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			// target is public ip literal, so it's reached through local proxy which answers itself
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				if tt.cookie != "" {
+					http.SetCookie(w, &http.Cookie{Name: "sid", Value: "new"})
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(proxy.Close)
+			cookies := &memCookies{cookies: make(map[string][][2]string)}
+			if tt.cookie != "" {
+				cookies.cookies[tt.cookie] = [][2]string{{"sid", "old"}, {"lang", "en"}}
+			}
+			e := newTestExtractor(t, noFlareURL, proxy.URL, cookies)
+
+			task := models.Task{TaskType: models.TaskTypeExtractJSON, URL: testAPIURL}
+			if tt.cookie != "" {
+				task.Headers = map[string]string{"Cookie": tt.cookie}
+			}
+			body, finalURL, err := e.fetchPlain(context.Background(), task)
+			require.NotNil(t, got)
+			assert.Equal(t, testAPIURL, got.URL.String())
+			assert.Equal(t, "application/json", got.Header.Get("Accept"))
+			assert.Equal(t, plainUserAgent, got.Header.Get("User-Agent"))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, string(body))
+			assert.Equal(t, testAPIURL, finalURL.String())
+			if tt.cookie != "" {
+				sid, err := got.Cookie("sid")
+				require.NoError(t, err)
+				assert.Equal(t, "old", sid.Value)
+				require.Len(t, cookies.updates, 1)
+				assert.Equal(t, tt.wantCookie, cookies.updates[0])
+			}
+		})
+	}
+}
+
+func TestFetchPlainRejectsReservedHost(t *testing.T) {
+	e := newTestExtractor(t, noFlareURL, "", nil)
+	_, _, err := e.fetchPlain(context.Background(), models.Task{
+		TaskType: models.TaskTypeExtractJSON,
+		URL:      "http://127.0.0.1/api",
+	})
+	var rejected *HostRejectedError
+	require.ErrorAs(t, err, &rejected)
+}
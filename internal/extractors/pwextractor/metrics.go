@@ -0,0 +1,23 @@
+package pwextractor
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	flareDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rssalchemy_flaresolverr_request_duration_seconds",
+		Help:    "FlareSolverr request latency by command and result.",
+		Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"cmd", "result"})
+	limiterWait = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "rssalchemy_domain_limiter_wait_seconds",
+		Help:    "Time tasks waited for per-domain limiter.",
+		Buckets: []float64{.1, .5, 1, 2, 5, 10, 30, 60},
+	})
+	limiterRejections = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rssalchemy_domain_limiter_rejections_total",
+		Help: "Tasks rejected by per-domain limiter.",
+	})
+)
@@ -1,37 +1,58 @@
 package pwextractor
 
 import (
+	"context"
 	"fmt"
+	"github.com/egor3f/rssalchemy/internal/dateparser"
 	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/transform"
 	"github.com/ericchiang/css"
 	"github.com/labstack/gommon/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/net/html"
 	"strings"
 )
 
 type htmlParser struct {
-	task       models.Task
-	dateParser DateParser
-	baseURL    *urlParts
+	task        models.Task
+	dateParser  DateParser
+	baseURL     *urlParts
+	transforms  fieldTransforms
+	dateOptions dateparser.Options
+	diag        *previewCollector
 }
 
-func (p *htmlParser) parse(htmlStr string) (*models.TaskResult, error) {
+func (p *htmlParser) parse(ctx context.Context, htmlStr string) (result *models.TaskResult, err error) {
+	_, span := tracer.Start(ctx, "htmlParser.parse")
+	defer func() {
+		endParseSpan(span, result, err)
+	}()
+
+	if p.transforms, err = newFieldTransforms(p.task); err != nil {
+		return nil, err
+	}
+	if p.dateOptions, err = newDateOptions(p.task); err != nil {
+		return nil, err
+	}
+
 	doc, err := html.Parse(strings.NewReader(htmlStr))
 	if err != nil {
 		return nil, fmt.Errorf("parse html: %w", err)
 	}
 
-	var result models.TaskResult
-	result.Title = textFromSelector(doc, "title")
+	result = &models.TaskResult{}
+	result.Title = textFromSelector(doc, "title", models.SelectorType_CSS)
 
-	icon, err := firstAttr(doc, "link[rel=apple-touch-icon]", "href")
+	icon, err := firstAttr(doc, "link[rel=apple-touch-icon]", models.SelectorType_CSS, "href")
 	if err != nil {
 		log.Warnf("page icon url: %v", err)
 	} else if icon != "" {
 		result.Icon = absURL(icon, p.baseURL)
 	}
 
-	postNodes, err := selectNodes(doc, p.task.SelectorPost)
+	postNodes, err := selectNodes(doc, p.task.SelectorPost, p.task.SelectorPostType)
 	if err != nil {
 		return nil, fmt.Errorf("post selector: %w", err)
 	}
@@ -39,15 +60,23 @@ func (p *htmlParser) parse(htmlStr string) (*models.TaskResult, error) {
 		return nil, fmt.Errorf("no posts on page")
 	}
 	log.Debugf("Posts count=%d", len(postNodes))
+	p.diag.page(result.Title, len(postNodes))
 
 	for _, post := range postNodes {
+		p.diag.startPost()
+		if p.diag != nil {
+			// counted before extraction, so posts skipped because of errors show real matches too
+			p.countMatches(post)
+		}
 		item, err := p.extractPost(post)
 		if err != nil {
 			log.Errorf("extract post fields: %v", err)
+			p.diag.skip(err.Error())
 			continue
 		}
-		if len(item.Title) == 0 || len(item.Link) == 0 || item.Created.IsZero() {
-			log.Warnf("post has no required fields, skip")
+		if missing := missingRequiredFields(item); len(missing) > 0 {
+			log.Warnf("post has no required fields (%s), skip", strings.Join(missing, ", "))
+			p.diag.skip(fmt.Sprintf("missing required fields: %s", strings.Join(missing, ", ")))
 			continue
 		}
 		result.Items = append(result.Items, item)
@@ -55,45 +84,72 @@ func (p *htmlParser) parse(htmlStr string) (*models.TaskResult, error) {
 	if len(result.Items) == 0 {
 		return nil, fmt.Errorf("extract failed for all posts")
 	}
-	return &result, nil
+	return result, nil
+}
+
+// endParseSpan records number of extracted items or parse error
+func endParseSpan(span trace.Span, result *models.TaskResult, err error) {
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(attribute.Int("rssalchemy.items", len(result.Items)))
+	}
+	span.End()
 }
 
 func (p *htmlParser) extractPost(post *html.Node) (models.FeedItem, error) {
 	var item models.FeedItem
+	raw := make(map[string]string)
 
-	item.Title = textFromSelector(post, p.task.SelectorTitle)
-	log.Debugf("---- POST: %s ----", item.Title)
-
-	item.Link = absURL(attrFromSelector(post, p.task.SelectorLink, "href"), p.baseURL)
+	var err error
+	raw[transform.FieldTitle], err = fieldFromSelector(post, p.task.SelectorTitle, p.task.SelectorTitleType, p.task.TitleExtractFrom, p.task.TitleAttributeName)
+	if err != nil {
+		return models.FeedItem{}, fmt.Errorf("title: %w", err)
+	}
+	raw[transform.FieldLink] = attrFromSelector(post, p.task.SelectorLink, p.task.SelectorLinkType, attrOrDefault(p.task.LinkAttributeName, "href"))
 
 	if len(p.task.SelectorDescription) > 0 {
-		item.Description = textFromSelector(post, p.task.SelectorDescription)
+		raw[transform.FieldDescription], err = fieldFromSelector(post, p.task.SelectorDescription, p.task.SelectorDescriptionType, p.task.DescriptionExtractFrom, p.task.DescriptionAttributeName)
+		if err != nil {
+			return models.FeedItem{}, fmt.Errorf("description: %w", err)
+		}
 	}
 
 	if len(p.task.SelectorAuthor) > 0 {
-		item.AuthorName = textFromSelector(post, p.task.SelectorAuthor)
-		item.AuthorLink = absURL(attrFromSelector(post, p.task.SelectorAuthor, "href"), p.baseURL)
+		raw[transform.FieldAuthor], err = fieldFromSelector(post, p.task.SelectorAuthor, p.task.SelectorAuthorType, p.task.AuthorExtractFrom, p.task.AuthorAttributeName)
+		if err != nil {
+			return models.FeedItem{}, fmt.Errorf("author: %w", err)
+		}
+		item.AuthorLink = absURL(attrFromSelector(post, p.task.SelectorAuthor, p.task.SelectorAuthorType, attrOrDefault(p.task.AuthorLinkAttributeName, "href")), p.baseURL)
 	}
 
 	if len(p.task.SelectorContent) > 0 {
-		item.Content = extractContentFromSelector(post, p.task.SelectorContent, p.baseURL)
+		raw[transform.FieldContent] = htmlFromSelector(post, p.task.SelectorContent, p.task.SelectorContentType)
 	}
 
 	if len(p.task.SelectorEnclosure) > 0 {
-		item.Enclosure = attrFromSelector(post, p.task.SelectorEnclosure, "src")
+		raw[transform.FieldEnclosure] = attrFromSelector(post, p.task.SelectorEnclosure, p.task.SelectorEnclosureType, attrOrDefault(p.task.EnclosureAttributeName, "src"))
 	}
 
-	createdDateStr := ""
-	switch p.task.CreatedExtractFrom {
-	case models.ExtractFrom_InnerText:
-		createdDateStr = textFromSelector(post, p.task.SelectorCreated)
-	case models.ExtractFrom_Attribute:
-		createdDateStr = attrFromSelector(post, p.task.SelectorCreated, p.task.CreatedAttributeName)
-	default:
-		return models.FeedItem{}, fmt.Errorf("invalid task.CreatedExtractFrom")
+	raw[transform.FieldCreated], err = fieldFromSelector(post, p.task.SelectorCreated, p.task.SelectorCreatedType, p.task.CreatedExtractFrom, p.task.CreatedAttributeName)
+	if err != nil {
+		return models.FeedItem{}, fmt.Errorf("created: %w", err)
 	}
+
+	fields := p.transforms.apply(raw, p.baseURL)
+	p.diag.values(raw, fields)
+	item.Title = fields[transform.FieldTitle]
+	log.Debugf("---- POST: %s ----", item.Title)
+	item.Link = absURL(fields[transform.FieldLink], p.baseURL)
+	item.Description = fields[transform.FieldDescription]
+	item.AuthorName = fields[transform.FieldAuthor]
+	item.Content = fields[transform.FieldContent]
+	item.Enclosure = absURL(fields[transform.FieldEnclosure], p.baseURL)
+
+	createdDateStr := fields[transform.FieldCreated]
 	log.Debugf("date=%s", createdDateStr)
-	createdDate, err := p.dateParser.ParseDate(createdDateStr)
+	createdDate, err := p.dateParser.ParseDate(createdDateStr, p.dateOptions)
+	p.diag.date(createdDate, err)
 	if err != nil {
 		log.Errorf("dateparser: %v", err)
 	} else {
@@ -103,19 +159,78 @@ func (p *htmlParser) extractPost(post *html.Node) (models.FeedItem, error) {
 	return item, nil
 }
 
-func selectNodes(root *html.Node, selector string) ([]*html.Node, error) {
+type fieldSelector struct {
+	field        string
+	selector     string
+	selectorType models.SelectorType
+}
+
+// taskFieldSelectors returns selectors of post fields, empty selectors included
+func taskFieldSelectors(task models.Task) []fieldSelector {
+	return []fieldSelector{
+		{transform.FieldTitle, task.SelectorTitle, task.SelectorTitleType},
+		{transform.FieldLink, task.SelectorLink, task.SelectorLinkType},
+		{transform.FieldDescription, task.SelectorDescription, task.SelectorDescriptionType},
+		{transform.FieldAuthor, task.SelectorAuthor, task.SelectorAuthorType},
+		{transform.FieldCreated, task.SelectorCreated, task.SelectorCreatedType},
+		{transform.FieldContent, task.SelectorContent, task.SelectorContentType},
+		{transform.FieldEnclosure, task.SelectorEnclosure, task.SelectorEnclosureType},
+	}
+}
+
+// countMatches records how many nodes every field selector matched inside post
+func (p *htmlParser) countMatches(post *html.Node) {
+	for _, fs := range taskFieldSelectors(p.task) {
+		if fs.selector == "" {
+			continue
+		}
+		nodes, err := selectNodes(post, fs.selector, fs.selectorType)
+		if err != nil {
+			log.Debugf("count matches of %s: %v", fs.field, err)
+		}
+		p.diag.selectorMatches(fs.field, len(nodes))
+	}
+}
+
+// fieldFromSelector returns inner text or attribute of the first selected node
+func fieldFromSelector(root *html.Node, selector string, selectorType models.SelectorType, extractFrom models.ExtractFrom, attrName string) (string, error) {
+	switch extractFrom {
+	case models.ExtractFrom_InnerText:
+		return textFromSelector(root, selector, selectorType), nil
+	case models.ExtractFrom_Attribute:
+		return attrFromSelector(root, selector, selectorType, attrName), nil
+	default:
+		return "", fmt.Errorf("invalid extract from: %d", extractFrom)
+	}
+}
+
+func attrOrDefault(attrName string, defaultName string) string {
+	if attrName == "" {
+		return defaultName
+	}
+	return attrName
+}
+
+func selectNodes(root *html.Node, selector string, selectorType models.SelectorType) ([]*html.Node, error) {
 	if strings.TrimSpace(selector) == "" {
 		return nil, fmt.Errorf("selector is empty")
 	}
-	sel, err := css.Parse(selector)
-	if err != nil {
-		return nil, err
+	switch selectorType {
+	case models.SelectorType_CSS:
+		sel, err := css.Parse(selector)
+		if err != nil {
+			return nil, err
+		}
+		return sel.Select(root), nil
+	case models.SelectorType_XPath:
+		return selectXPath(root, selector)
+	default:
+		return nil, fmt.Errorf("invalid selector type: %d", selectorType)
 	}
-	return sel.Select(root), nil
 }
 
-func firstNode(root *html.Node, selector string) (*html.Node, error) {
-	nodes, err := selectNodes(root, selector)
+func firstNode(root *html.Node, selector string, selectorType models.SelectorType) (*html.Node, error) {
+	nodes, err := selectNodes(root, selector, selectorType)
 	if err != nil {
 		return nil, err
 	}
@@ -125,24 +240,29 @@ func firstNode(root *html.Node, selector string) (*html.Node, error) {
 	return nodes[0], nil
 }
 
-func textFromSelector(root *html.Node, selector string) string {
-	node, err := firstNode(root, selector)
+func textFromSelector(root *html.Node, selector string, selectorType models.SelectorType) string {
+	node, err := firstNode(root, selector, selectorType)
 	if err != nil || node == nil {
 		return ""
 	}
 	return nodeText(node)
 }
 
-func attrFromSelector(root *html.Node, selector string, attrName string) string {
-	node, err := firstNode(root, selector)
+// attrFromSelector returns attribute of the first selected node.
+// XPath selectors can select attribute or string value directly, in that case it is returned as is.
+func attrFromSelector(root *html.Node, selector string, selectorType models.SelectorType, attrName string) string {
+	node, err := firstNode(root, selector, selectorType)
 	if err != nil || node == nil {
 		return ""
 	}
+	if node.Type == html.TextNode {
+		return strings.TrimSpace(node.Data)
+	}
 	return nodeAttr(node, attrName)
 }
 
-func firstAttr(root *html.Node, selector string, attrName string) (string, error) {
-	node, err := firstNode(root, selector)
+func firstAttr(root *html.Node, selector string, selectorType models.SelectorType, attrName string) (string, error) {
+	node, err := firstNode(root, selector, selectorType)
 	if err != nil {
 		return "", err
 	}
@@ -152,6 +272,7 @@ func firstAttr(root *html.Node, selector string, attrName string) (string, error
 	return nodeAttr(node, attrName), nil
 }
 
+// nodeText returns text of node and its descendants, whitespace is collapsed
 func nodeText(n *html.Node) string {
 	var b strings.Builder
 	var walk func(*html.Node)
@@ -165,7 +286,7 @@ func nodeText(n *html.Node) string {
 		}
 	}
 	walk(n)
-	return strings.TrimSpace(b.String())
+	return strings.Join(strings.Fields(b.String()), " ")
 }
 
 func nodeAttr(n *html.Node, name string) string {
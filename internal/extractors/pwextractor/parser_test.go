@@ -0,0 +1,200 @@
+package pwextractor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/egor3f/rssalchemy/internal/dateparser"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseTestPage parses page with htmlParser and returns the first item
+func parseTestPage(t *testing.T, task models.Task, page string) models.FeedItem {
+	t.Helper()
+	p := htmlParser{
+		task:       task,
+		dateParser: &dateparser.DateParser{CurrentTimeFunc: func() time.Time { return FixtureTime }},
+		baseURL:    parseURL(task.URL),
+	}
+	result, err := p.parse(context.Background(), page)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Items)
+	return result.Items[0]
+}
+
+func TestHTMLParserContentTransforms(t *testing.T) {
+	page := `<html><body><div class="post">
+<a href="/1">First &lt;b&gt;post&lt;/b&gt;</a><time>2025-01-09 10:00</time>
+<div class="text"><p onclick="x()">Hello <b>world</b></p><script>alert(1)</script><img src="/i.png"></div>
+</div></body></html>`
+	task := models.Task{
+		TaskType:        models.TaskTypeExtract,
+		URL:             testPageURL,
+		SelectorPost:    "div.post",
+		SelectorTitle:   "a",
+		SelectorLink:    "a",
+		SelectorCreated: "time",
+		SelectorContent: "div.text",
+	}
+	tests := []struct {
+		name      string
+		transform string
+		want      string
+	}{
+		{
+			name: "no transform",
+			want: `<p>Hello <b>world </b></p><img src="http://93.184.215.14/i.png"/>`,
+		},
+		{
+			name:      "prefix can't inject markup",
+			transform: `prefix <script>alert(2)</script><a href="javascript:x()">click</a>`,
+			want:      `<p>click Hello <b>world </b></p><img src="http://93.184.215.14/i.png"/>`,
+		},
+		{
+			name:      "replace can't inject markup",
+			transform: `replace /Hello/ <iframe src="https://evil.example.com"></iframe>Bye`,
+			want:      `<p>Bye <b>world </b></p><img src="http://93.184.215.14/i.png"/>`,
+		},
+		{
+			name:      "template escapes text fields",
+			transform: `template <b>{title}</b>{value}`,
+			want:      `<p><b>First &lt;b&gt;post&lt;/b&gt; </b>Hello <b>world </b></p><img src="http://93.184.215.14/i.png"/>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := task
+			task.TransformContent = tt.transform
+			item := parseTestPage(t, task, page)
+			assert.Equal(t, tt.want, item.Content)
+		})
+	}
+}
+
+func TestHTMLParserContentInTemplates(t *testing.T) {
+	page := `<html><body><div class="post">
+<a href="/1">First</a><time>2025-01-09 10:00</time><div class="text"><script>alert(1)</script>Hi</div>
+</div></body></html>`
+	item := parseTestPage(t, models.Task{
+		TaskType:             models.TaskTypeExtract,
+		URL:                  testPageURL,
+		SelectorPost:         "div.post",
+		SelectorTitle:        "a",
+		SelectorLink:         "a",
+		SelectorCreated:      "time",
+		SelectorContent:      "div.text",
+		SelectorDescription:  "a",
+		TransformDescription: "template {content}",
+	}, page)
+	assert.Equal(t, "<p>Hi</p>", item.Description)
+}
+
+func TestHTMLParserTextWhitespace(t *testing.T) {
+	page := `<html><body><div class="post">
+<a href="/1">
+	Multiline
+	title
+</a><time>2025-01-09 10:00</time>
+<p>Text <b>with</b> markup</p>
+</div></body></html>`
+	item := parseTestPage(t, models.Task{
+		TaskType:            models.TaskTypeExtract,
+		URL:                 testPageURL,
+		SelectorPost:        "div.post",
+		SelectorTitle:       "a",
+		SelectorLink:        "a",
+		SelectorCreated:     "time",
+		SelectorDescription: "p",
+	}, page)
+	assert.Equal(t, "Multiline title", item.Title)
+	assert.Equal(t, "Text with markup", item.Description)
+}
+
+func TestHTMLParserAttributes(t *testing.T) {
+	page := `<html><body><div class="post" data-id="42">
+<a class="title" href="/1" data-href="/alt/1" title="Title from attribute">Title from text</a>
+<p class="desc" data-summary="Summary">Description text</p>
+<a class="author" href="/u/bob" data-profile="/profile/bob" data-name="Bob">bob</a>
+<time datetime="2025-01-09T10:00:00Z">yesterday</time>
+<img src="/i.png" data-src="/full.png">
+</div></body></html>`
+	base := models.Task{
+		TaskType:          models.TaskTypeExtract,
+		URL:               testPageURL,
+		SelectorPost:      "div.post",
+		SelectorTitle:     "a.title",
+		SelectorLink:      "a.title",
+		SelectorAuthor:    "a.author",
+		SelectorCreated:   "time",
+		SelectorEnclosure: "img",
+	}
+	tests := []struct {
+		name   string
+		modify func(task *models.Task)
+		check  func(t *testing.T, item models.FeedItem)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, item models.FeedItem) {
+				assert.Equal(t, "Title from text", item.Title)
+				assert.Equal(t, "http://93.184.215.14/1", item.Link)
+				assert.Equal(t, "bob", item.AuthorName)
+				assert.Equal(t, "http://93.184.215.14/u/bob", item.AuthorLink)
+				assert.Equal(t, "http://93.184.215.14/i.png", item.Enclosure)
+			},
+		},
+		{
+			name: "custom link, author link and enclosure attributes",
+			modify: func(task *models.Task) {
+				task.LinkAttributeName = "data-href"
+				task.AuthorLinkAttributeName = "data-profile"
+				task.EnclosureAttributeName = "data-src"
+			},
+			check: func(t *testing.T, item models.FeedItem) {
+				assert.Equal(t, "http://93.184.215.14/alt/1", item.Link)
+				assert.Equal(t, "http://93.184.215.14/profile/bob", item.AuthorLink)
+				assert.Equal(t, "http://93.184.215.14/full.png", item.Enclosure)
+			},
+		},
+		{
+			name: "fields from attributes",
+			modify: func(task *models.Task) {
+				task.TitleExtractFrom, task.TitleAttributeName = models.ExtractFrom_Attribute, "title"
+				task.SelectorDescription = "p.desc"
+				task.DescriptionExtractFrom, task.DescriptionAttributeName = models.ExtractFrom_Attribute, "data-summary"
+				task.AuthorExtractFrom, task.AuthorAttributeName = models.ExtractFrom_Attribute, "data-name"
+				task.CreatedExtractFrom, task.CreatedAttributeName = models.ExtractFrom_Attribute, "datetime"
+			},
+			check: func(t *testing.T, item models.FeedItem) {
+				assert.Equal(t, "Title from attribute", item.Title)
+				assert.Equal(t, "Summary", item.Description)
+				assert.Equal(t, "Bob", item.AuthorName)
+				assert.Equal(t, "http://93.184.215.14/u/bob", item.AuthorLink)
+				assert.Equal(t, time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC), item.Created.UTC())
+			},
+		},
+		{
+			name: "xpath attribute",
+			modify: func(task *models.Task) {
+				task.SelectorTitle, task.SelectorTitleType = "@data-id", models.SelectorType_XPath
+				task.SelectorLink, task.SelectorLinkType = ".//a[@class='title']/@data-href", models.SelectorType_XPath
+			},
+			check: func(t *testing.T, item models.FeedItem) {
+				assert.Equal(t, "42", item.Title)
+				assert.Equal(t, "http://93.184.215.14/alt/1", item.Link)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := base
+			if tt.modify != nil {
+				tt.modify(&task)
+			}
+			tt.check(t, parseTestPage(t, task, page))
+		})
+	}
+}
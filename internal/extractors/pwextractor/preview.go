@@ -0,0 +1,136 @@
+package pwextractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/transform"
+	"time"
+)
+
+// previewCollector records diagnostics while parsing. All methods are no-op on nil collector,
+// so parsers call them unconditionally and regular extraction pays nothing.
+type previewCollector struct {
+	result models.PreviewTaskResult
+}
+
+func (c *previewCollector) page(title string, postsMatched int) {
+	if c == nil {
+		return
+	}
+	c.result.Title = title
+	c.result.PostsMatched = postsMatched
+}
+
+func (c *previewCollector) startPost() {
+	if c == nil {
+		return
+	}
+	c.result.Posts = append(c.result.Posts, models.PostPreview{
+		SelectorMatches: make(map[string]int),
+	})
+}
+
+func (c *previewCollector) current() *models.PostPreview {
+	if c == nil || len(c.result.Posts) == 0 {
+		return nil
+	}
+	return &c.result.Posts[len(c.result.Posts)-1]
+}
+
+func (c *previewCollector) selectorMatches(field string, count int) {
+	if post := c.current(); post != nil {
+		post.SelectorMatches[field] = count
+	}
+}
+
+func (c *previewCollector) values(raw map[string]string, fields map[string]string) {
+	if post := c.current(); post != nil {
+		post.Raw = raw
+		post.Fields = fields
+	}
+}
+
+func (c *previewCollector) date(created time.Time, err error) {
+	post := c.current()
+	if post == nil {
+		return
+	}
+	if err != nil {
+		post.DateError = err.Error()
+		return
+	}
+	post.Created = &created
+}
+
+func (c *previewCollector) skip(reason string) {
+	if post := c.current(); post != nil {
+		post.Skipped = true
+		post.SkipReason = reason
+	}
+}
+
+// missingRequiredFields returns names of required fields which are empty in feed item
+func missingRequiredFields(item models.FeedItem) []string {
+	var missing []string
+	if len(item.Title) == 0 {
+		missing = append(missing, transform.FieldTitle)
+	}
+	if len(item.Link) == 0 {
+		missing = append(missing, transform.FieldLink)
+	}
+	if item.Created.IsZero() {
+		missing = append(missing, transform.FieldCreated)
+	}
+	return missing
+}
+
+// Preview runs extraction and returns diagnostics instead of feed.
+// Extraction errors are reported inside result, so wizard always gets an answer.
+// Only *HostRejectedError is returned, so client gets the same 403 as for render.
+func (e *PwExtractor) Preview(ctx context.Context, task models.Task) (*models.PreviewTaskResult, error) {
+	collector := &previewCollector{}
+	var err error
+
+	switch task.TaskType {
+	case models.TaskTypePreview:
+		var solution *flareSolution
+		var baseURL *urlParts
+		solution, baseURL, err = e.fetchSolution(ctx, task, false)
+		if err == nil {
+			parser := htmlParser{task: task, dateParser: e.dateParser, baseURL: baseURL, diag: collector}
+			_, err = parser.parse(ctx, solution.Response)
+		}
+	case models.TaskTypePreviewJSON:
+		var body []byte
+		var baseURL *urlParts
+		body, baseURL, err = e.fetchPlain(ctx, task)
+		if err == nil {
+			parser := jsonParser{task: task, dateParser: e.dateParser, baseURL: baseURL, diag: collector}
+			_, err = parser.parse(ctx, body)
+		}
+	default:
+		return nil, fmt.Errorf("invalid preview task type: %s", task.TaskType)
+	}
+
+	var rejected *HostRejectedError
+	if errors.As(err, &rejected) {
+		return nil, err
+	}
+	if err != nil {
+		collector.result.Error = err.Error()
+	}
+	return &collector.result, nil
+}
+
+// PreviewPage parses saved html page like Preview does, without fetching it, for offline selector debugging.
+// Links are resolved against baseURL.
+func PreviewPage(ctx context.Context, task models.Task, page string, baseURL string, dateParser DateParser) *models.PreviewTaskResult {
+	collector := &previewCollector{}
+	parser := htmlParser{task: task, dateParser: dateParser, baseURL: parseURL(baseURL), diag: collector}
+	if _, err := parser.parse(ctx, page); err != nil {
+		collector.result.Error = err.Error()
+	}
+	return &collector.result
+}
@@ -0,0 +1,173 @@
+package pwextractor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/egor3f/rssalchemy/internal/dateparser"
+	"github.com/egor3f/rssalchemy/internal/extractors/pwextractor/flaretest"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewPage(t *testing.T) {
+	page := `<html><head><title>News</title></head><body>
+<div class="post"><a href="/1">First</a><time>2025-01-09 10:00</time></div>
+<div class="post"><span>No link</span><time>2025-01-08 10:00</time></div>
+</body></html>`
+	task := models.Task{
+		TaskType:        models.TaskTypeExtract,
+		URL:             "https://example.com/news",
+		SelectorPost:    "div.post",
+		SelectorTitle:   "a, span",
+		SelectorLink:    "a",
+		SelectorCreated: "time",
+	}
+	dp := &dateparser.DateParser{CurrentTimeFunc: func() time.Time { return FixtureTime }}
+
+	result := PreviewPage(context.Background(), task, page, "https://mirror.example.com/saved/", dp)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, "News", result.Title)
+	assert.Equal(t, 2, result.PostsMatched)
+	require.Len(t, result.Posts, 2)
+
+	assert.False(t, result.Posts[0].Skipped)
+	assert.Equal(t, 1, result.Posts[0].SelectorMatches["link"])
+	assert.Equal(t, "/1", result.Posts[0].Raw["link"])
+	require.NotNil(t, result.Posts[0].Created)
+
+	assert.True(t, result.Posts[1].Skipped)
+	assert.Equal(t, 0, result.Posts[1].SelectorMatches["link"])
+	assert.Contains(t, result.Posts[1].SkipReason, "link")
+
+	result = PreviewPage(context.Background(), task, "<html><body></body></html>", task.URL, dp)
+	assert.Equal(t, "no posts on page", result.Error)
+}
+
+func TestPreviewPageSkippedPostMatches(t *testing.T) {
+	page := `<html><body>
+<div class="post"><a href="/1">First</a><a href="/1b">Second link</a><time>2025-01-09 10:00</time></div>
+</body></html>`
+	task := models.Task{
+		TaskType:         models.TaskTypeExtract,
+		URL:              "https://example.com/news",
+		SelectorPost:     "div.post",
+		SelectorTitle:    "a",
+		TitleExtractFrom: models.ExtractFrom(42),
+		SelectorLink:     "a",
+		SelectorCreated:  "time",
+	}
+	dp := &dateparser.DateParser{CurrentTimeFunc: func() time.Time { return FixtureTime }}
+
+	result := PreviewPage(context.Background(), task, page, task.URL, dp)
+	assert.Equal(t, "extract failed for all posts", result.Error)
+	require.Len(t, result.Posts, 1)
+	post := result.Posts[0]
+	assert.True(t, post.Skipped)
+	assert.Contains(t, post.SkipReason, "invalid extract from")
+	assert.Equal(t, map[string]int{"title": 2, "link": 2, "created": 1}, post.SelectorMatches)
+}
+
+func TestPreview(t *testing.T) {
+	flare := flaretest.NewServer()
+	defer flare.Close()
+	flare.SetPage(testPageURL, flaretest.Page{HTML: testPage})
+	e := newTestExtractor(t, flare.URL, "", nil)
+
+	tests := []struct {
+		name      string
+		task      models.Task
+		wantErr   string
+		wantPosts int
+	}{
+		{
+			name: "ok",
+			task: models.Task{
+				TaskType:        models.TaskTypePreview,
+				URL:             testPageURL,
+				SelectorPost:    "div.post",
+				SelectorTitle:   "a",
+				SelectorLink:    "a",
+				SelectorCreated: "time",
+			},
+			wantPosts: 2,
+		},
+		{
+			name: "no posts",
+			task: models.Task{
+				TaskType:        models.TaskTypePreview,
+				URL:             testPageURL,
+				SelectorPost:    "article",
+				SelectorTitle:   "a",
+				SelectorLink:    "a",
+				SelectorCreated: "time",
+			},
+			wantErr: "no posts on page",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := e.Preview(context.Background(), tt.task)
+			require.NoError(t, err)
+			if tt.wantErr != "" {
+				assert.Contains(t, result.Error, tt.wantErr)
+			} else {
+				assert.Empty(t, result.Error)
+			}
+			assert.Equal(t, tt.wantPosts, result.PostsMatched)
+			require.Len(t, result.Posts, tt.wantPosts)
+			for _, post := range result.Posts {
+				assert.False(t, post.Skipped)
+				assert.Equal(t, 1, post.SelectorMatches["title"])
+				assert.NotNil(t, post.Created)
+			}
+		})
+	}
+
+	_, err := e.Preview(context.Background(), models.Task{TaskType: models.TaskTypeExtract, URL: testPageURL})
+	assert.Error(t, err)
+
+	// rejected host is returned as error, so worker stores it as task error and client gets 403
+	_, err = e.Preview(context.Background(), models.Task{
+		TaskType: models.TaskTypePreview, URL: "http://127.0.0.1/news", SelectorPost: "div",
+	})
+	var rejected *HostRejectedError
+	require.ErrorAs(t, err, &rejected)
+	payload, err := TaskResultPayload(nil, err)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"task_error_kind": "rejected", "task_error": "host 127.0.0.1 is not allowed: reserved address"}`, string(payload))
+}
+
+func TestPreviewJSON(t *testing.T) {
+	// target is public ip literal, so it's reached through local proxy which answers itself
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"posts": [
+			{"title": "First", "url": "/1", "created": "2025-01-09 10:00"},
+			{"title": "No link", "created": "2025-01-08 10:00"}
+		]}`))
+	}))
+	defer proxy.Close()
+	e := newTestExtractor(t, noFlareURL, proxy.URL, nil)
+
+	result, err := e.Preview(context.Background(), models.Task{
+		TaskType:        models.TaskTypePreviewJSON,
+		URL:             testAPIURL,
+		SelectorPost:    "posts",
+		SelectorTitle:   "title",
+		SelectorLink:    "url",
+		SelectorCreated: "created",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, 2, result.PostsMatched)
+	require.Len(t, result.Posts, 2)
+	assert.False(t, result.Posts[0].Skipped)
+	assert.Equal(t, "/1", result.Posts[0].Raw["link"])
+	assert.True(t, result.Posts[1].Skipped)
+	assert.Equal(t, 0, result.Posts[1].SelectorMatches["link"])
+	assert.Equal(t, 1, result.Posts[1].SelectorMatches["title"])
+}
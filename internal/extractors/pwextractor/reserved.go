@@ -0,0 +1,72 @@
+package pwextractor
+
+import (
+	"net"
+)
+
+// reservedNetworks are IANA special-purpose ranges which must never be fetched.
+// Ranges embedding IPv4 address (IPv4-compatible, NAT64, 6to4, Teredo) are denied entirely,
+// because embedded address can point to internal network.
+var reservedNetworks = mustParseCIDRs(
+	// IPv4
+	"0.0.0.0/8",          // "this" network
+	"10.0.0.0/8",         // private
+	"100.64.0.0/10",      // carrier-grade NAT
+	"127.0.0.0/8",        // loopback
+	"169.254.0.0/16",     // link local (cloud metadata)
+	"172.16.0.0/12",      // private
+	"192.0.0.0/24",       // IETF protocol assignments
+	"192.0.2.0/24",       // documentation TEST-NET-1
+	"192.88.99.0/24",     // 6to4 relay anycast
+	"192.168.0.0/16",     // private
+	"198.18.0.0/15",      // benchmarking
+	"198.51.100.0/24",    // documentation TEST-NET-2
+	"203.0.113.0/24",     // documentation TEST-NET-3
+	"224.0.0.0/4",        // multicast
+	"240.0.0.0/4",        // reserved
+	"255.255.255.255/32", // broadcast
+	// IPv6
+	"::/128",         // unspecified
+	"::1/128",        // loopback
+	"::/96",          // IPv4-compatible (deprecated)
+	"64:ff9b::/96",   // NAT64
+	"64:ff9b:1::/48", // local-use NAT64
+	"100::/64",       // discard
+	"2001::/23",      // IETF protocol assignments, including Teredo
+	"2001:db8::/32",  // documentation
+	"2002::/16",      // 6to4
+	"fc00::/7",       // unique local
+	"fe80::/10",      // link local
+	"fec0::/10",      // site local (deprecated)
+	"ff00::/8",       // multicast
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// isReservedIP reports whether ip belongs to any reserved range.
+// IPv4-mapped IPv6 addresses are checked as IPv4 (::ffff:0:0/96 can't be in the list,
+// net.IPNet treats it as 0.0.0.0/0).
+func isReservedIP(ip net.IP) bool {
+	if ip == nil {
+		return true
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package pwextractor
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsReservedIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		ip       string
+		reserved bool
+	}{
+		{name: "public IPv4", ip: "93.184.216.34", reserved: false},
+		{name: "public IPv6", ip: "2606:2800:220:1:248:1893:25c8:1946", reserved: false},
+		{name: "this network", ip: "0.1.2.3", reserved: true},
+		{name: "unspecified IPv4", ip: "0.0.0.0", reserved: true},
+		{name: "private 10/8", ip: "10.1.2.3", reserved: true},
+		{name: "CGNAT", ip: "100.64.0.1", reserved: true},
+		{name: "CGNAT upper bound", ip: "100.127.255.254", reserved: true},
+		{name: "after CGNAT", ip: "100.128.0.1", reserved: false},
+		{name: "loopback", ip: "127.0.0.1", reserved: true},
+		{name: "loopback range", ip: "127.1.2.3", reserved: true},
+		{name: "cloud metadata", ip: "169.254.169.254", reserved: true},
+		{name: "private 172.16/12", ip: "172.31.255.255", reserved: true},
+		{name: "after 172.16/12", ip: "172.32.0.1", reserved: false},
+		{name: "IETF assignments", ip: "192.0.0.8", reserved: true},
+		{name: "documentation TEST-NET-1", ip: "192.0.2.1", reserved: true},
+		{name: "6to4 relay", ip: "192.88.99.1", reserved: true},
+		{name: "private 192.168/16", ip: "192.168.1.1", reserved: true},
+		{name: "benchmarking", ip: "198.19.255.1", reserved: true},
+		{name: "documentation TEST-NET-2", ip: "198.51.100.7", reserved: true},
+		{name: "documentation TEST-NET-3", ip: "203.0.113.7", reserved: true},
+		{name: "multicast", ip: "239.255.255.250", reserved: true},
+		{name: "reserved 240/4", ip: "250.1.2.3", reserved: true},
+		{name: "broadcast", ip: "255.255.255.255", reserved: true},
+		{name: "unspecified IPv6", ip: "::", reserved: true},
+		{name: "loopback IPv6", ip: "::1", reserved: true},
+		{name: "IPv4-mapped loopback", ip: "::ffff:127.0.0.1", reserved: true},
+		{name: "IPv4-mapped private", ip: "::ffff:10.0.0.1", reserved: true},
+		{name: "IPv4-mapped public", ip: "::ffff:93.184.216.34", reserved: false},
+		{name: "IPv4-compatible loopback", ip: "::127.0.0.1", reserved: true},
+		{name: "IPv4-compatible public", ip: "::93.184.216.34", reserved: true},
+		{name: "NAT64", ip: "64:ff9b::7f00:1", reserved: true},
+		{name: "local-use NAT64", ip: "64:ff9b:1::a00:1", reserved: true},
+		{name: "discard", ip: "100::1", reserved: true},
+		{name: "Teredo", ip: "2001:0:4136:e378:8000:63bf:3fff:fdd2", reserved: true},
+		{name: "documentation IPv6", ip: "2001:db8::1", reserved: true},
+		{name: "6to4", ip: "2002:7f00:1::1", reserved: true},
+		{name: "unique local", ip: "fd00::1", reserved: true},
+		{name: "link local IPv6", ip: "fe80::1", reserved: true},
+		{name: "site local", ip: "fec0::1", reserved: true},
+		{name: "multicast IPv6", ip: "ff02::1", reserved: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := net.ParseIP(tt.ip)
+			require.NotNil(t, ip)
+			assert.Equal(t, tt.reserved, isReservedIP(ip))
+		})
+	}
+}
+
+func TestHTTPClientRejectsReserved(t *testing.T) {
+	e := &PwExtractor{}
+	client, err := newHTTPClient("", 5*time.Second, e.checkHost, e.checkIPs)
+	require.NoError(t, err)
+
+	t.Run("pinned dialer", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(200)
+		}))
+		defer srv.Close()
+		_, err := client.Get(srv.URL)
+		var rejected *HostRejectedError
+		assert.True(t, errors.As(err, &rejected), "unexpected error: %v", err)
+	})
+
+	redirects := []struct {
+		name    string
+		target  string
+		wantErr bool
+	}{
+		{name: "public ip", target: "http://93.184.216.34/feed", wantErr: false},
+		{name: "private ip", target: "http://10.0.0.1/admin", wantErr: true},
+		{name: "metadata", target: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{name: "IPv4-mapped loopback", target: "http://[::ffff:127.0.0.1]:8080/", wantErr: true},
+		{name: "NAT64", target: "http://[64:ff9b::a9fe:a9fe]/", wantErr: true},
+	}
+	for _, tt := range redirects {
+		t.Run("redirect to "+tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.target, nil)
+			require.NoError(t, err)
+			err = client.CheckRedirect(req, []*http.Request{req})
+			if tt.wantErr {
+				var rejected *HostRejectedError
+				assert.ErrorAs(t, err, &rejected)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("too many redirects", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "http://93.184.216.34/", nil)
+		require.NoError(t, err)
+		assert.Error(t, client.CheckRedirect(req, make([]*http.Request, maxRedirects)))
+	})
+}
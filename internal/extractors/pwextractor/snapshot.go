@@ -0,0 +1,222 @@
+package pwextractor
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/transform"
+	"github.com/labstack/gommon/log"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	highlightPostAttr  = "data-rssalchemy-post"
+	highlightFieldAttr = "data-rssalchemy-field"
+
+	// chrome doesn't navigate to longer urls
+	maxDataURLLength = 2 * 1024 * 1024
+)
+
+// highlightColors are outline colours of posts and fields, so drifted selector is visible at a glance
+var highlightColors = []struct {
+	field string
+	color string
+}{
+	{transform.FieldTitle, "#2196f3"},
+	{transform.FieldLink, "#4caf50"},
+	{transform.FieldCreated, "#ff9800"},
+	{transform.FieldDescription, "#9c27b0"},
+	{transform.FieldAuthor, "#009688"},
+	{transform.FieldContent, "#607d8b"},
+	{transform.FieldEnclosure, "#795548"},
+}
+
+func highlightStyle() string {
+	var b strings.Builder
+	b.WriteString("[data-rssalchemy-post] { outline: 3px dashed #e91e63 !important; outline-offset: 2px; }\n")
+	for _, hc := range highlightColors {
+		fmt.Fprintf(&b, "[%s~=%q] { outline: 2px solid %s !important; }\n", highlightFieldAttr, hc.field, hc.color)
+	}
+	return b.String()
+}
+
+// Snapshot returns html of the page as it was rendered by browser.
+// If task has post selector, selected nodes are marked with data-rssalchemy-* attributes.
+func (e *PwExtractor) Snapshot(ctx context.Context, task models.Task) (result *models.SnapshotTaskResult, errRet error) {
+	solution, baseURL, err := e.fetchSolution(ctx, task, false)
+	if err != nil {
+		return nil, err
+	}
+	if task.SelectorPost == "" {
+		return &models.SnapshotTaskResult{HTML: solution.Response}, nil
+	}
+	highlighted, err := highlightSelectors(solution.Response, task, baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("highlight selectors: %w", err)
+	}
+	return &models.SnapshotTaskResult{HTML: highlighted}, nil
+}
+
+func highlightSelectors(htmlStr string, task models.Task, baseURL *urlParts) (string, error) {
+	doc, err := html.Parse(strings.NewReader(htmlStr))
+	if err != nil {
+		return "", fmt.Errorf("parse html: %w", err)
+	}
+	if err := annotate(doc, task, baseURL); err != nil {
+		return "", err
+	}
+	return renderHTML(doc)
+}
+
+// screenshotOverlay renders annotated page once more and takes screenshot of it.
+// Scripts are removed, otherwise they could rebuild dom and drop annotations.
+// Browser loads resources of page again relative to injected <base>, so overlay render is rate limited
+// and host checked like the page itself; proxy is applied by doFlareRequest.
+func (e *PwExtractor) screenshotOverlay(ctx context.Context, solution *flareSolution, task models.Task, baseURL *urlParts) (*flareSolution, error) {
+	pageURL := task.URL
+	if baseURL != nil {
+		pageURL = baseURL.String()
+	}
+	if err := e.checkTask(ctx, models.Task{URL: pageURL}); err != nil {
+		return nil, err
+	}
+	if err := e.verifyHost(ctx, pageURL); err != nil {
+		return nil, fmt.Errorf("verify host: %w", err)
+	}
+
+	doc, err := html.Parse(strings.NewReader(solution.Response))
+	if err != nil {
+		return nil, fmt.Errorf("parse html: %w", err)
+	}
+	if err := annotate(doc, task, baseURL); err != nil {
+		return nil, err
+	}
+	removeScripts(doc)
+	annotated, err := renderHTML(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	dataURL := "data:text/html;base64," + base64.StdEncoding.EncodeToString([]byte(annotated))
+	if len(dataURL) > maxDataURLLength {
+		return nil, fmt.Errorf("page is too large for overlay: %d bytes", len(annotated))
+	}
+	resp, err := e.doFlareRequest(ctx, flareRequest{
+		Cmd:              "request.get",
+		Url:              dataURL,
+		MaxTimeout:       e.maxTimeoutMs,
+		ReturnScreenshot: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Solution == nil {
+		return nil, fmt.Errorf("empty flaresolverr solution")
+	}
+	return resp.Solution, nil
+}
+
+// annotate marks posts and fields matched by task selectors and injects highlight styles
+func annotate(doc *html.Node, task models.Task, baseURL *urlParts) error {
+	posts, err := selectNodes(doc, task.SelectorPost, task.SelectorPostType)
+	if err != nil {
+		return fmt.Errorf("post selector: %w", err)
+	}
+	for i, post := range posts {
+		markNode(post, highlightPostAttr, strconv.Itoa(i+1))
+		for _, fs := range taskFieldSelectors(task) {
+			if fs.selector == "" {
+				continue
+			}
+			nodes, err := selectNodes(post, fs.selector, fs.selectorType)
+			if err != nil {
+				log.Debugf("highlight %s: %v", fs.field, err)
+				continue
+			}
+			for _, n := range nodes {
+				markNode(n, highlightFieldAttr, fs.field)
+			}
+		}
+	}
+
+	injectHead(doc, baseURL)
+	return nil
+}
+
+func renderHTML(doc *html.Node) (string, error) {
+	var b strings.Builder
+	if err := html.Render(&b, doc); err != nil {
+		return "", fmt.Errorf("render html: %w", err)
+	}
+	return b.String(), nil
+}
+
+func removeScripts(n *html.Node) {
+	for child := n.FirstChild; child != nil; {
+		next := child.NextSibling
+		if child.Type == html.ElementNode && (child.DataAtom == atom.Script || child.DataAtom == atom.Noscript) {
+			n.RemoveChild(child)
+		} else {
+			removeScripts(child)
+		}
+		child = next
+	}
+}
+
+// markNode adds value to space separated attribute of element.
+// Text nodes are marked via their parent, synthesized nodes (xpath attributes and strings) are skipped.
+func markNode(n *html.Node, attrName string, value string) {
+	if n.Type == html.TextNode {
+		n = n.Parent
+	}
+	if n == nil || n.Type != html.ElementNode {
+		return
+	}
+	for i, a := range n.Attr {
+		if a.Key == attrName {
+			if !slices.Contains(strings.Fields(a.Val), value) {
+				n.Attr[i].Val = a.Val + " " + value
+			}
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: attrName, Val: value})
+}
+
+// injectHead adds highlight styles and base url, so relative resources load when snapshot is opened
+func injectHead(doc *html.Node, baseURL *urlParts) {
+	head := findElement(doc, atom.Head)
+	if head == nil {
+		return
+	}
+	style := &html.Node{Type: html.ElementNode, DataAtom: atom.Style, Data: "style"}
+	style.AppendChild(&html.Node{Type: html.TextNode, Data: highlightStyle()})
+	head.AppendChild(style)
+
+	if baseURL != nil && findElement(head, atom.Base) == nil {
+		base := &html.Node{
+			Type:     html.ElementNode,
+			DataAtom: atom.Base,
+			Data:     "base",
+			Attr:     []html.Attribute{{Key: "href", Val: baseURL.String()}},
+		}
+		head.InsertBefore(base, head.FirstChild)
+	}
+}
+
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
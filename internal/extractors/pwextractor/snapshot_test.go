@@ -0,0 +1,134 @@
+package pwextractor
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/egor3f/rssalchemy/internal/dateparser"
+	"github.com/egor3f/rssalchemy/internal/extractors/pwextractor/flaretest"
+	"github.com/egor3f/rssalchemy/internal/limiter"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightSelectors(t *testing.T) {
+	page := `<html><head><title>t</title></head><body>
+<div class="post"><a class="title" href="/1">First</a><span class="date">today</span></div>
+<div class="post"><a class="title" href="/2">Second</a></div>
+</body></html>`
+	task := models.Task{
+		SelectorPost:        "div.post",
+		SelectorTitle:       "a.title",
+		SelectorLink:        "a.title",
+		SelectorCreated:     ".//span/text()",
+		SelectorCreatedType: models.SelectorType_XPath,
+	}
+
+	result, err := highlightSelectors(page, task, parseURL("https://example.com/news"))
+	require.NoError(t, err)
+
+	assert.Contains(t, result, `<base href="https://example.com/news"/>`)
+	assert.Contains(t, result, `<div class="post" data-rssalchemy-post="1">`)
+	assert.Contains(t, result, `<div class="post" data-rssalchemy-post="2">`)
+	assert.Contains(t, result, `<a class="title" href="/1" data-rssalchemy-field="title link">`)
+	assert.Contains(t, result, `<span class="date" data-rssalchemy-field="created">`)
+	assert.Contains(t, result, `[data-rssalchemy-post]`)
+}
+
+// keysLimiter records limited keys and rejects after allowed number of calls
+type keysLimiter struct {
+	mu      sync.Mutex
+	keys    []string
+	allowed int
+}
+
+func (l *keysLimiter) Limit(_ context.Context, key string) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.keys = append(l.keys, key)
+	if len(l.keys) > l.allowed {
+		return 0, limiter.ErrLimitReached
+	}
+	return 0, nil
+}
+
+func TestScreenshotOverlay(t *testing.T) {
+	page := `<html><head><title>News</title><script>document.body.innerHTML = ""</script></head><body>
+<div class="post"><a href="/1">First</a><time>2025-01-09 10:00</time></div>
+</body></html>`
+	task := models.Task{
+		TaskType:        models.TaskTypePageScreenshot,
+		URL:             testPageURL,
+		SelectorPost:    "div.post",
+		SelectorTitle:   "a",
+		SelectorLink:    "a",
+		SelectorCreated: "time",
+	}
+	tests := []struct {
+		name         string
+		page         flaretest.Page
+		allowed      int
+		wantErr      bool
+		wantRequests int
+	}{
+		{name: "ok", page: flaretest.Page{HTML: page}, allowed: 2, wantRequests: 2},
+		{name: "overlay is rate limited", page: flaretest.Page{HTML: page}, allowed: 1, wantErr: true, wantRequests: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flare := flaretest.NewServer()
+			defer flare.Close()
+			flare.SetPage(testPageURL, tt.page)
+			lim := &keysLimiter{allowed: tt.allowed}
+			e, err := New(Config{
+				Proxy:                  testProxyURL,
+				DateParser:             &dateparser.DateParser{CurrentTimeFunc: func() time.Time { return FixtureTime }},
+				CookieManager:          &memCookies{cookies: make(map[string][][2]string)},
+				Limiter:                lim,
+				FlareSolverrURL:        flare.URL,
+				FlareSolverrMaxTimeout: 200,
+			})
+			require.NoError(t, err)
+
+			result, err := e.Screenshot(context.Background(), task)
+			requests := flare.Requests()
+			require.Len(t, requests, tt.wantRequests)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, limiter.ErrLimitReached)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, flaretest.Screenshot, result.Image)
+			assert.Equal(t, []string{"93.184.215.14", "93.184.215.14"}, lim.keys)
+
+			overlay := requests[1]
+			assert.Equal(t, &flaretest.Proxy{Url: testProxyURL}, overlay.Proxy)
+			assert.True(t, overlay.ReturnScreenshot)
+			encoded, ok := strings.CutPrefix(overlay.Url, "data:text/html;base64,")
+			require.True(t, ok)
+			annotated, err := base64.StdEncoding.DecodeString(encoded)
+			require.NoError(t, err)
+			assert.Contains(t, string(annotated), `<base href="`+testPageURL+`"/>`)
+			assert.Contains(t, string(annotated), `data-rssalchemy-post="1"`)
+			assert.NotContains(t, string(annotated), "<script>")
+		})
+	}
+}
+
+func TestScreenshotOverlayRejectedRedirect(t *testing.T) {
+	flare := flaretest.NewServer()
+	defer flare.Close()
+	e := newTestExtractor(t, flare.URL, "", nil)
+
+	// final url of the first render is base of overlay, so it's checked before the second render
+	_, err := e.screenshotOverlay(context.Background(), &flareSolution{Response: "<html></html>"},
+		models.Task{URL: testPageURL, SelectorPost: "div"}, parseURL("http://127.0.0.1/news"))
+	var rejected *HostRejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.Empty(t, flare.Requests())
+}
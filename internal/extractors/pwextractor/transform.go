@@ -0,0 +1,68 @@
+package pwextractor
+
+import (
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/dateparser"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/egor3f/rssalchemy/internal/transform"
+	"maps"
+)
+
+// fieldTransforms holds compiled transforms of task fields
+type fieldTransforms map[string]*transform.Pipeline
+
+func newFieldTransforms(task models.Task) (fieldTransforms, error) {
+	sources := map[string]string{
+		transform.FieldTitle:       task.TransformTitle,
+		transform.FieldLink:        task.TransformLink,
+		transform.FieldDescription: task.TransformDescription,
+		transform.FieldAuthor:      task.TransformAuthor,
+		transform.FieldCreated:     task.TransformCreated,
+		transform.FieldContent:     task.TransformContent,
+		transform.FieldEnclosure:   task.TransformEnclosure,
+	}
+	transforms := make(fieldTransforms)
+	for field, src := range sources {
+		if src == "" {
+			continue
+		}
+		parse := transform.Parse
+		if field == transform.FieldContent {
+			parse = transform.ParseHTML
+		}
+		pipeline, err := parse(src)
+		if err != nil {
+			return nil, fmt.Errorf("%s transform: %w", field, err)
+		}
+		transforms[field] = pipeline
+	}
+	return transforms, nil
+}
+
+// apply transforms raw field values. Templates always see raw values,
+// so the result doesn't depend on order of fields.
+// Raw content is html of third party: it's transformed as is and sanitized afterwards,
+// so transforms can't inject markup; templates of other fields see it sanitized.
+func (t fieldTransforms) apply(raw map[string]string, baseURL *urlParts) map[string]string {
+	sanitized := maps.Clone(raw)
+	sanitized[transform.FieldContent] = sanitizeContent(raw[transform.FieldContent], baseURL)
+
+	result := make(map[string]string, len(raw))
+	for _, field := range transform.Fields {
+		fields := sanitized
+		if field == transform.FieldContent {
+			fields = raw
+		}
+		result[field] = t[field].Apply(raw[field], fields)
+	}
+	result[transform.FieldContent] = sanitizeContent(result[transform.FieldContent], baseURL)
+	return result
+}
+
+func newDateOptions(task models.Task) (dateparser.Options, error) {
+	opts, err := dateparser.ParseOptions(task.DateFormats, task.DateLanguages, task.DateTimezone, task.DateDayFirst)
+	if err != nil {
+		return dateparser.Options{}, fmt.Errorf("date options: %w", err)
+	}
+	return opts, nil
+}
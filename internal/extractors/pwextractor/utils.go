@@ -1,6 +1,7 @@
 package pwextractor
 
 import (
+	"context"
 	"fmt"
 	"github.com/jellydator/ttlcache/v3"
 	"net"
@@ -131,19 +132,46 @@ func getIPs(host string) ([]net.IP, error) {
 	return ips, nil
 }
 
-func (e *PwExtractor) allowHost(rawUrl string) (bool, error) {
+// checkHost rejects reserved addresses, proxy address and hosts rejected by host policy.
+// Resolved addresses are cached, use verifyHost to check with fresh lookup.
+func (e *PwExtractor) checkHost(rawUrl string) error {
 	ips, err := getIPs(rawUrl)
 	if err != nil {
-		return false, fmt.Errorf("allow host get ips: %w", err)
+		return fmt.Errorf("check host get ips: %w", err)
 	}
+	return e.checkIPs(urlHostname(rawUrl), ips)
+}
+
+// verifyHost is checkHost without dns cache. Browser resolves host itself, so page could be loaded
+// from address different from checked one (dns rebinding); it's verified again after loading.
+func (e *PwExtractor) verifyHost(ctx context.Context, rawUrl string) error {
+	host := urlHostname(rawUrl)
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("verify host lookup ip: %w", err)
+	}
+	return e.checkIPs(host, ips)
+}
+
+func (e *PwExtractor) checkIPs(host string, ips []net.IP) error {
 	for _, ip := range ips {
-		deny := ip.IsPrivate() || ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast()
-		if e.proxyIP != nil {
-			deny = deny || e.proxyIP.Equal(ip)
+		if isReservedIP(ip) {
+			return &HostRejectedError{Host: host, Reason: "reserved address"}
 		}
-		if deny {
-			return false, nil
+		if e.proxyIP != nil && e.proxyIP.Equal(ip) {
+			return &HostRejectedError{Host: host, Reason: "proxy address"}
 		}
 	}
-	return true, nil
+	if e.hostPolicy != nil {
+		return e.hostPolicy.check(host, ips)
+	}
+	return nil
+}
+
+// urlHostname returns hostname of url, or input itself if it's not url
+func urlHostname(rawUrl string) string {
+	if parsed, err := url.Parse(rawUrl); err == nil && parsed.Host != "" {
+		return parsed.Hostname()
+	}
+	return rawUrl
 }
@@ -0,0 +1,47 @@
+package pwextractor
+
+import (
+	"fmt"
+	"github.com/antchfx/htmlquery"
+	"github.com/antchfx/xpath"
+	"golang.org/x/net/html"
+	"strconv"
+)
+
+// selectXPath evaluates xpath expression with root as the root node of the document, so for field selectors
+// the post is the root: //a and .//a both search inside the post, absolute paths like /html/body don't match.
+// Since attributes are not nodes in html package, selected attributes are returned as text nodes with
+// attribute value. Results of other types (e.g. string functions) are returned as a single text node,
+// or nothing if the string is empty.
+func selectXPath(root *html.Node, selector string) ([]*html.Node, error) {
+	expr, err := xpath.Compile(selector)
+	if err != nil {
+		return nil, fmt.Errorf("xpath %q: %w", selector, err)
+	}
+	var value string
+	switch v := expr.Evaluate(htmlquery.CreateXPathNavigator(root)).(type) {
+	case *xpath.NodeIterator:
+		var nodes []*html.Node
+		for v.MoveNext() {
+			nav := v.Current().(*htmlquery.NodeNavigator)
+			if nav.NodeType() == xpath.AttributeNode {
+				nodes = append(nodes, &html.Node{Type: html.TextNode, Data: nav.Value()})
+			} else {
+				nodes = append(nodes, nav.Current())
+			}
+		}
+		return nodes, nil
+	case string:
+		value = v
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		value = strconv.FormatBool(v)
+	default:
+		return nil, fmt.Errorf("xpath %q: unexpected result type %T", selector, v)
+	}
+	if value == "" {
+		return nil, nil
+	}
+	return []*html.Node{{Type: html.TextNode, Data: value}}, nil
+}
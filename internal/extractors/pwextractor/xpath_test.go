@@ -0,0 +1,83 @@
+package pwextractor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/html"
+)
+
+const xpathTestDoc = `<html><head><title>Test page</title></head><body>
+<div class="post" id="p1">
+  <h2><a href="/one" title="First">Post one</a></h2>
+  <dl><dt>Author</dt><dd>alice</dd><dt>Published</dt><dd>2024-01-02</dd></dl>
+</div>
+<div class="post featured" id="p2">
+  <h2><a href="/two">Post two</a></h2>
+  <dl><dt>Published</dt><dd>2024-02-03</dd></dl>
+</div>
+<p>footer <b>bold</b> text</p>
+</body></html>`
+
+func xpathNodeString(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	return strings.TrimSpace(nodeText(n))
+}
+
+func TestSelectXPath(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(xpathTestDoc))
+	require.NoError(t, err)
+	posts, err := selectXPath(doc, "//div[contains(@class, 'post')]")
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+
+	tests := []struct {
+		name     string
+		root     *html.Node
+		expr     string
+		expected []string
+	}{
+		{"absolute path", doc, "/html/head/title", []string{"Test page"}},
+		{"descendants", doc, "//h2/a", []string{"Post one", "Post two"}},
+		{"attribute value", doc, "//a/@href", []string{"/one", "/two"}},
+		{"following sibling", doc, "//dt[.='Published']/following-sibling::dd[1]", []string{"2024-01-02", "2024-02-03"}},
+		{"count", doc, "count(//dd)", []string{"3"}},
+		{"string function", doc, "normalize-space(//p)", []string{"footer bold text"}},
+		{"bool", doc, "boolean(//article)", []string{"false"}},
+		{"empty result", doc, "//article", nil},
+		{"empty string", doc, "string(//article)", nil},
+
+		// field selectors are evaluated with post as the root
+		{"relative descendants", posts[1], ".//a/@href", []string{"/two"}},
+		{"descendants of post", posts[1], "//a/@href", []string{"/two"}},
+		{"post attribute", posts[1], "@id", []string{"p2"}},
+		{"absolute path in post", posts[1], "/html/body//a", nil},
+		{"parent of post", posts[1], "../p/b", []string{"bold"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := selectXPath(tt.root, tt.expr)
+			require.NoError(t, err)
+			var result []string
+			for _, n := range nodes {
+				result = append(result, xpathNodeString(n))
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSelectXPathErrors(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(xpathTestDoc))
+	require.NoError(t, err)
+	for _, expr := range []string{"", "//div[", "//div[@id='x'", "unknown-func()", "'unterminated", "1 +"} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := selectXPath(doc, expr)
+			assert.Error(t, err)
+		})
+	}
+}
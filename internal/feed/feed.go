@@ -0,0 +1,118 @@
+package feed
+
+import (
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/gorilla/feeds"
+	"github.com/labstack/gommon/log"
+	"html"
+	"net/url"
+	"time"
+)
+
+const (
+	FormatAtom = "atom"
+	FormatRSS  = "rss"
+	FormatJSON = "json"
+)
+
+// Formats are supported output formats of Render
+var Formats = []string{FormatAtom, FormatRSS, FormatJSON}
+
+// build makes feed of task result. Titles are html-escaped for xml formats, because readers treat them as html.
+func build(task models.Task, result models.TaskResult, escapeTitles bool) (*feeds.Feed, error) {
+	title := func(s string) string {
+		if escapeTitles {
+			return html.EscapeString(s)
+		}
+		return s
+	}
+	feedTS := time.Now()
+	if len(result.Items) > 0 {
+		feedTS = result.Items[0].Created
+	}
+	feed := feeds.Feed{
+		Title:   title(result.Title),
+		Link:    &feeds.Link{Href: task.URL},
+		Updated: feedTS,
+	}
+	for _, item := range result.Items {
+		itemUrl, err := url.Parse(item.Link)
+		if err != nil {
+			log.Errorf("Invalid item link, item=%+v", item)
+			continue
+		}
+		id := fmt.Sprintf(
+			"tag:%s,%s:%s",
+			itemUrl.Host,
+			anyTimeFormat("2006-01-02", item.Created, item.Updated),
+			itemUrl.Path,
+		)
+		if len(itemUrl.RawQuery) > 0 {
+			id += "?" + itemUrl.RawQuery
+		}
+		feed.Items = append(feed.Items, &feeds.Item{
+			Id:          id,
+			Title:       title(item.Title),
+			Link:        &feeds.Link{Href: item.Link},
+			Author:      &feeds.Author{Name: item.AuthorName},
+			Description: item.Description,
+			Created:     item.Created,
+			Updated:     item.Updated,
+			Content:     item.Content,
+		})
+	}
+	if len(feed.Items) == 0 {
+		return nil, fmt.Errorf("empty feed")
+	}
+	return &feed, nil
+}
+
+// Atom renders task result as served by render endpoint
+func Atom(task models.Task, result models.TaskResult) (string, error) {
+	feed, err := build(task, result, true)
+	if err != nil {
+		return "", err
+	}
+	atomFeed := (&feeds.Atom{Feed: feed}).AtomFeed()
+	atomFeed.Icon = result.Icon
+	for i, entry := range atomFeed.Entries {
+		if entry.Author != nil {
+			entry.Author.Uri = result.Items[i].AuthorLink
+		}
+	}
+	atom, err := feeds.ToXML(atomFeed)
+	if err != nil {
+		return "", fmt.Errorf("feed to xml: %w", err)
+	}
+	return atom, nil
+}
+
+// Render renders task result in one of Formats
+func Render(format string, task models.Task, result models.TaskResult) (string, error) {
+	if format == FormatAtom {
+		return Atom(task, result)
+	}
+	feed, err := build(task, result, format != FormatJSON)
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case FormatRSS:
+		return feed.ToRss()
+	case FormatJSON:
+		return feed.ToJSON()
+	default:
+		return "", fmt.Errorf("unknown feed format: %s", format)
+	}
+}
+
+// returns the first non-zero time formatted as a string or ""
+func anyTimeFormat(format string, times ...time.Time) string {
+	for _, t := range times {
+		if !t.IsZero() {
+			return t.Format(format)
+		}
+	}
+	return ""
+}
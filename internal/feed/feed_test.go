@@ -0,0 +1,54 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/egor3f/rssalchemy/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	task := models.Task{URL: "https://example.com/news"}
+	created := time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC)
+	result := models.TaskResult{
+		Title: "News & more",
+		Icon:  "https://example.com/favicon.ico",
+		Items: []models.FeedItem{{
+			Title:      "First",
+			Link:       "https://example.com/posts/1?page=2",
+			AuthorName: "alice",
+			AuthorLink: "https://example.com/users/alice",
+			Created:    created,
+		}},
+	}
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{FormatAtom, []string{
+			"<title>News &amp;amp; more</title>",
+			"<id>tag:example.com,2025-01-09:/posts/1?page=2</id>",
+			"<icon>https://example.com/favicon.ico</icon>",
+			"<uri>https://example.com/users/alice</uri>",
+		}},
+		{FormatRSS, []string{"<title>News &amp;amp; more</title>", "<link>https://example.com/posts/1?page=2</link>"}},
+		{FormatJSON, []string{`"title": "News \u0026 more"`, `"id": "tag:example.com,2025-01-09:/posts/1?page=2"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out, err := Render(tt.format, task, result)
+			require.NoError(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, out, want)
+			}
+		})
+	}
+
+	_, err := Render("yaml", task, result)
+	assert.Error(t, err)
+	_, err = Render(FormatAtom, task, models.TaskResult{Title: "empty"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadyHandler(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	fail := func(context.Context) error { return fmt.Errorf("connection refused") }
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := []struct {
+		name   string
+		checks map[string]Check
+		code   int
+		want   Report
+	}{
+		{
+			name:   "no checks",
+			checks: nil,
+			code:   200,
+			want:   Report{Status: StatusOK},
+		},
+		{
+			name:   "all ok",
+			checks: map[string]Check{"nats": ok, "redis": ok},
+			code:   200,
+			want: Report{Status: StatusOK, Checks: map[string]CheckResult{
+				"nats":  {Status: StatusOK},
+				"redis": {Status: StatusOK},
+			}},
+		},
+		{
+			name:   "one failed",
+			checks: map[string]Check{"nats": ok, "redis": fail},
+			code:   503,
+			want: Report{Status: StatusFail, Checks: map[string]CheckResult{
+				"nats":  {Status: StatusOK},
+				"redis": {Status: StatusFail, Error: "connection refused"},
+			}},
+		},
+		{
+			name:   "timeout",
+			checks: map[string]Check{"flaresolverr": hang},
+			code:   503,
+			want: Report{Status: StatusFail, Checks: map[string]CheckResult{
+				"flaresolverr": {Status: StatusFail, Error: "context deadline exceeded"},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := New()
+			checker.timeout = 50 * time.Millisecond
+			for name, check := range tt.checks {
+				checker.Add(name, check)
+			}
+			rec := httptest.NewRecorder()
+			checker.ReadyHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			assert.Equal(t, tt.code, rec.Code)
+
+			var got Report
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLiveHandler(t *testing.T) {
+	checker := New().Add("nats", func(context.Context) error { return fmt.Errorf("down") })
+	rec := httptest.NewRecorder()
+	checker.LiveHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, 200, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
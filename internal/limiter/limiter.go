@@ -11,3 +11,18 @@ var ErrLimitReached = fmt.Errorf("limit reached")
 type Limiter interface {
 	Limit(ctx context.Context, key string) (waitFor time.Duration, err error)
 }
+
+// State is a state of limiter for one key
+type State struct {
+	Key string `json:"key"`
+	// Requests waiting for their turn, including the last one
+	Queued int64 `json:"queued"`
+	// Time when queue is drained
+	QueuedUntil time.Time `json:"queued_until"`
+}
+
+// Inspector is implemented by limiters which state can be viewed and cleared by admin
+type Inspector interface {
+	States(ctx context.Context) ([]State, error)
+	Reset(ctx context.Context, key string) error
+}
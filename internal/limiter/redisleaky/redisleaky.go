@@ -11,6 +11,8 @@ import (
 	"github.com/mennanov/limiters"
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/time/rate"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -39,8 +41,18 @@ func New(
 	return &l
 }
 
+func (l *Limiter) limiterKey(key string) string {
+	return fmt.Sprintf("limiter_%s_%s", l.prefix, key)
+}
+
+// stateKeys are redis keys of bucket state, in format of limiters.LeakyBucketRedis
+func (l *Limiter) stateKeys(key string) (last string, version string) {
+	prefix := fmt.Sprintf("%s_state", l.limiterKey(key))
+	return fmt.Sprintf("{%s}last", prefix), fmt.Sprintf("{%s}version", prefix)
+}
+
 func (l *Limiter) Limit(ctx context.Context, key string) (time.Duration, error) {
-	limiterKey := fmt.Sprintf("limiter_%s_%s", l.prefix, key)
+	limiterKey := l.limiterKey(key)
 	bucket := limiters.NewLeakyBucket(
 		l.capacity,
 		l.rate,
@@ -61,6 +73,49 @@ func (l *Limiter) Limit(ctx context.Context, key string) (time.Duration, error)
 	return wait, err
 }
 
+// States returns keys used recently (state expires when bucket is drained)
+func (l *Limiter) States(ctx context.Context) ([]limiter.State, error) {
+	lastPattern, _ := l.stateKeys("*")
+	keyPrefix, keySuffix, _ := strings.Cut(lastPattern, "*")
+	now := time.Now()
+	var states []limiter.State
+	iter := l.redisClient.Scan(ctx, 0, lastPattern, 100).Iterator()
+	for iter.Next(ctx) {
+		redisKey := iter.Val()
+		last, err := l.redisClient.Get(ctx, redisKey).Int64()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get %s: %w", redisKey, err)
+		}
+		state := limiter.State{
+			Key:         strings.TrimSuffix(strings.TrimPrefix(redisKey, keyPrefix), keySuffix),
+			QueuedUntil: time.Unix(0, last),
+		}
+		if state.QueuedUntil.After(now) {
+			state.Queued = int64(state.QueuedUntil.Sub(now)/l.rate) + 1
+		}
+		states = append(states, state)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("scan limiter keys: %w", err)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Key < states[j].Key
+	})
+	return states, nil
+}
+
+// Reset clears state of key, so next request is not delayed
+func (l *Limiter) Reset(ctx context.Context, key string) error {
+	last, version := l.stateKeys(key)
+	if err := l.redisClient.Del(ctx, last, version).Err(); err != nil {
+		return fmt.Errorf("delete limiter state: %w", err)
+	}
+	return nil
+}
+
 type logger struct {
 }
 
@@ -0,0 +1,47 @@
+package redisleaky
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"testing"
+	"time"
+)
+
+func TestStatesAndReset(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	// 1 request per minute, so queue doesn't drain during test
+	l := New(rate.Every(time.Minute), 10, client, "test")
+	for range 3 {
+		_, err := l.Limit(ctx, "example.com")
+		require.NoError(t, err)
+	}
+	_, err := l.Limit(ctx, "example.org")
+	require.NoError(t, err)
+
+	states, err := l.States(ctx)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.Equal(t, "example.com", states[0].Key)
+	assert.Equal(t, int64(2), states[0].Queued) // first request went without waiting
+	assert.WithinDuration(t, time.Now().Add(2*time.Minute), states[0].QueuedUntil, 5*time.Second)
+	assert.Equal(t, "example.org", states[1].Key)
+	assert.Equal(t, int64(0), states[1].Queued)
+
+	require.NoError(t, l.Reset(ctx, "example.com"))
+	states, err = l.States(ctx)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, "example.org", states[0].Key)
+
+	wait, err := l.Limit(ctx, "example.com")
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"crypto/sha256"
 	"fmt"
+	"hash"
 	"time"
 )
 
@@ -10,7 +11,11 @@ type TaskType string
 
 const (
 	TaskTypeExtract        = "extract"
+	TaskTypeExtractJSON    = "extract_json"
 	TaskTypePageScreenshot = "page_screenshot"
+	TaskTypePageSnapshot   = "page_snapshot"
+	TaskTypePreview        = "preview"
+	TaskTypePreviewJSON    = "preview_json"
 )
 
 type ExtractFrom int
@@ -20,21 +25,56 @@ const (
 	ExtractFrom_Attribute ExtractFrom = 1
 )
 
+type SelectorType int
+
+const (
+	SelectorType_CSS   SelectorType = 0
+	SelectorType_XPath SelectorType = 1
+)
+
 type Task struct {
 	// While adding new fields, dont forget to alter caching func
-	TaskType             TaskType
-	URL                  string
-	SelectorPost         string
-	SelectorTitle        string
-	SelectorLink         string
-	SelectorDescription  string
-	SelectorAuthor       string
-	SelectorCreated      string
-	CreatedExtractFrom   ExtractFrom
-	CreatedAttributeName string
-	SelectorContent      string
-	SelectorEnclosure    string
-	Headers              map[string]string
+	TaskType                 TaskType
+	URL                      string
+	SelectorPost             string
+	SelectorPostType         SelectorType
+	SelectorTitle            string
+	SelectorTitleType        SelectorType
+	TitleExtractFrom         ExtractFrom
+	TitleAttributeName       string
+	SelectorLink             string
+	SelectorLinkType         SelectorType
+	LinkAttributeName        string // empty means href
+	SelectorDescription      string
+	SelectorDescriptionType  SelectorType
+	DescriptionExtractFrom   ExtractFrom
+	DescriptionAttributeName string
+	SelectorAuthor           string
+	SelectorAuthorType       SelectorType
+	AuthorExtractFrom        ExtractFrom
+	AuthorAttributeName      string
+	AuthorLinkAttributeName  string // empty means href
+	SelectorCreated          string
+	SelectorCreatedType      SelectorType
+	CreatedExtractFrom       ExtractFrom
+	CreatedAttributeName     string
+	DateFormats              string // newline separated
+	DateLanguages            string // comma separated
+	DateTimezone             string
+	DateDayFirst             bool
+	SelectorContent          string
+	SelectorContentType      SelectorType
+	SelectorEnclosure        string
+	SelectorEnclosureType    SelectorType
+	EnclosureAttributeName   string // empty means src
+	TransformTitle           string
+	TransformLink            string
+	TransformDescription     string
+	TransformAuthor          string
+	TransformCreated         string
+	TransformContent         string
+	TransformEnclosure       string
+	Headers                  map[string]string
 }
 
 func (t Task) CacheKey() string {
@@ -49,9 +89,54 @@ func (t Task) CacheKey() string {
 	h.Write([]byte(t.SelectorContent))
 	h.Write([]byte(t.SelectorEnclosure))
 	h.Write([]byte(fmt.Sprintf("%+v", t.Headers)))
+	writeNonDefault(h, "post_type", t.SelectorPostType)
+	writeNonDefault(h, "title_type", t.SelectorTitleType)
+	writeNonDefault(h, "link_type", t.SelectorLinkType)
+	writeNonDefault(h, "description_type", t.SelectorDescriptionType)
+	writeNonDefault(h, "author_type", t.SelectorAuthorType)
+	writeNonDefault(h, "created_type", t.SelectorCreatedType)
+	writeNonDefault(h, "content_type", t.SelectorContentType)
+	writeNonDefault(h, "enclosure_type", t.SelectorEnclosureType)
+	writeNonDefault(h, "created_extract_from", t.CreatedExtractFrom)
+	writeNonDefault(h, "created_attribute_name", t.CreatedAttributeName)
+	writeNonDefault(h, "date_formats", t.DateFormats)
+	writeNonDefault(h, "date_languages", t.DateLanguages)
+	writeNonDefault(h, "date_timezone", t.DateTimezone)
+	writeNonDefault(h, "date_day_first", t.DateDayFirst)
+	writeNonDefault(h, "title_extract_from", t.TitleExtractFrom)
+	writeNonDefault(h, "title_attribute_name", t.TitleAttributeName)
+	writeNonDefault(h, "link_attribute_name", t.LinkAttributeName)
+	writeNonDefault(h, "description_extract_from", t.DescriptionExtractFrom)
+	writeNonDefault(h, "description_attribute_name", t.DescriptionAttributeName)
+	writeNonDefault(h, "author_extract_from", t.AuthorExtractFrom)
+	writeNonDefault(h, "author_attribute_name", t.AuthorAttributeName)
+	writeNonDefault(h, "author_link_attribute_name", t.AuthorLinkAttributeName)
+	writeNonDefault(h, "enclosure_attribute_name", t.EnclosureAttributeName)
+	writeNonDefault(h, "transform_title", t.TransformTitle)
+	writeNonDefault(h, "transform_link", t.TransformLink)
+	writeNonDefault(h, "transform_description", t.TransformDescription)
+	writeNonDefault(h, "transform_author", t.TransformAuthor)
+	writeNonDefault(h, "transform_created", t.TransformCreated)
+	writeNonDefault(h, "transform_content", t.TransformContent)
+	writeNonDefault(h, "transform_enclosure", t.TransformEnclosure)
 	return fmt.Sprintf("%s_%x", t.TaskType, h.Sum(nil))
 }
 
+// SpecKey identifies task regardless of request headers, so all cached variants of a spec can be found
+func (t Task) SpecKey() string {
+	t.Headers = nil
+	return t.CacheKey()
+}
+
+// writeNonDefault hashes only non-zero values, so cache keys of tasks which
+// don't use newer fields stay the same
+func writeNonDefault[T comparable](h hash.Hash, name string, value T) {
+	var zero T
+	if value != zero {
+		h.Write([]byte(fmt.Sprintf("%s=%v", name, value)))
+	}
+}
+
 type FeedItem struct {
 	Title       string
 	Created     time.Time
@@ -73,3 +158,63 @@ type TaskResult struct {
 type ScreenshotTaskResult struct {
 	Image []byte // png
 }
+
+type SnapshotTaskResult struct {
+	HTML string
+}
+
+// PreviewTaskResult contains diagnostics of extraction for spec debugging in wizard
+type PreviewTaskResult struct {
+	Error        string        `json:"error,omitempty"`
+	Title        string        `json:"title"`
+	PostsMatched int           `json:"posts_matched"`
+	Posts        []PostPreview `json:"posts"`
+}
+
+type PostPreview struct {
+	SelectorMatches map[string]int    `json:"selector_matches"` // field name -> count of matched nodes
+	Raw             map[string]string `json:"raw"`              // values as extracted by selectors
+	Fields          map[string]string `json:"fields"`           // values after transforms
+	Created         *time.Time        `json:"created,omitempty"`
+	DateError       string            `json:"date_error,omitempty"`
+	Skipped         bool              `json:"skipped"`
+	SkipReason      string            `json:"skip_reason,omitempty"`
+}
+
+const TaskErrorRejected = "rejected"
+
+// TaskError is stored instead of result when task fails with error which should be shown to client
+// (instead of waiting for timeout). Only deterministic errors are stored, because they are cached.
+type TaskError struct {
+	Kind  string `json:"task_error_kind"`
+	Error string `json:"task_error"`
+}
+
+// ApiKey holds settings of api key. Secret itself is never stored, its hash is used as key id.
+type ApiKey struct {
+	Name           string    `json:"name"`
+	Admin          bool      `json:"admin"`
+	RateLimitEvery float64   `json:"rate_limit_every,omitempty"` // seconds per task, 0 means default
+	RateLimitBurst int       `json:"rate_limit_burst,omitempty"` // 0 means default
+	DailyQuota     int       `json:"daily_quota,omitempty"`      // tasks per day, 0 means default, negative - unlimited
+	Created        time.Time `json:"created"`
+}
+
+// CacheEntry describes cached task result for admin api. It never contains task headers (cookies).
+type CacheEntry struct {
+	Key      string    `json:"key"`
+	SpecKey  string    `json:"spec_key"`
+	TaskType TaskType  `json:"task_type"`
+	URL      string    `json:"url"`
+	Domain   string    `json:"domain"`
+	Created  time.Time `json:"created"`
+	Size     int       `json:"size"`
+}
+
+// ShortLink is encoded specs stored under short id. Owner token itself is never stored, only its hash.
+type ShortLink struct {
+	Specs          string    `json:"specs"`
+	OwnerTokenHash string    `json:"owner_token_hash"`
+	Created        time.Time `json:"created"`
+	Updated        time.Time `json:"updated"`
+}
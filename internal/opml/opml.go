@@ -0,0 +1,102 @@
+// Package opml reads and writes subscription lists in OPML 2.0 format, as exported by feed readers.
+package opml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+const ContentType = "text/x-opml"
+
+type OPML struct {
+	XMLName xml.Name  `xml:"opml"`
+	Version string    `xml:"version,attr"`
+	Head    Head      `xml:"head"`
+	Body    []Outline `xml:"body>outline"`
+}
+
+type Head struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+// Outline is feed if XMLURL is set, otherwise it's folder of readers
+type Outline struct {
+	Type     string    `xml:"type,attr,omitempty"`
+	Text     string    `xml:"text,attr"`
+	Title    string    `xml:"title,attr,omitempty"`
+	XMLURL   string    `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string    `xml:"htmlUrl,attr,omitempty"`
+	Outlines []Outline `xml:"outline"`
+}
+
+// Feed is subscription of OPML
+type Feed struct {
+	Title   string
+	XMLURL  string
+	HTMLURL string
+}
+
+// New makes OPML of feeds without folders
+func New(title string, feeds []Feed) OPML {
+	doc := OPML{
+		Version: "2.0",
+		Head:    Head{Title: title, DateCreated: time.Now().UTC().Format(time.RFC1123Z)},
+	}
+	for _, f := range feeds {
+		doc.Body = append(doc.Body, Outline{
+			Type:    "rss",
+			Text:    f.Title,
+			Title:   f.Title,
+			XMLURL:  f.XMLURL,
+			HTMLURL: f.HTMLURL,
+		})
+	}
+	return doc
+}
+
+func (o OPML) Write(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("write opml: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(o); err != nil {
+		return fmt.Errorf("encode opml: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("write opml: %w", err)
+	}
+	return nil
+}
+
+// Parse reads OPML document
+func Parse(r io.Reader) (OPML, error) {
+	var doc OPML
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return OPML{}, fmt.Errorf("decode opml: %w", err)
+	}
+	return doc, nil
+}
+
+// Feeds returns subscriptions from all folders in document order
+func (o OPML) Feeds() []Feed {
+	var feeds []Feed
+	var walk func(outlines []Outline)
+	walk = func(outlines []Outline) {
+		for _, outline := range outlines {
+			if outline.XMLURL != "" {
+				title := outline.Title
+				if title == "" {
+					title = outline.Text
+				}
+				feeds = append(feeds, Feed{Title: title, XMLURL: outline.XMLURL, HTMLURL: outline.HTMLURL})
+			}
+			walk(outline.Outlines)
+		}
+	}
+	walk(o.Body)
+	return feeds
+}
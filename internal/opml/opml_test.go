@@ -0,0 +1,51 @@
+package opml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	feeds := []Feed{
+		{Title: "News & more", XMLURL: "https://rss.example.com/api/v1/render/1:abc?sig=x&y=1", HTMLURL: "https://example.com/news"},
+		{Title: "Blog", XMLURL: "https://rss.example.com/api/v1/feed/AbCdEfGhIjK"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, New("subscriptions", feeds).Write(&buf))
+	assert.Contains(t, buf.String(), `xmlUrl="https://rss.example.com/api/v1/render/1:abc?sig=x&amp;y=1"`)
+
+	doc, err := Parse(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, "subscriptions", doc.Head.Title)
+	assert.Equal(t, feeds, doc.Feeds())
+}
+
+func TestParseFolders(t *testing.T) {
+	// typical export of feed reader: feeds in folders, text without title
+	doc, err := Parse(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<opml version="1.0">
+  <head><title>Reader export</title></head>
+  <body>
+    <outline text="Tech">
+      <outline type="rss" text="First" xmlUrl="https://a.example.com/feed"/>
+      <outline text="Nested">
+        <outline type="rss" text="Second" title="Second title" xmlUrl="https://b.example.com/feed" htmlUrl="https://b.example.com"/>
+      </outline>
+    </outline>
+    <outline type="rss" text="Third" xmlUrl="https://c.example.com/feed"/>
+  </body>
+</opml>`))
+	require.NoError(t, err)
+	assert.Equal(t, []Feed{
+		{Title: "First", XMLURL: "https://a.example.com/feed"},
+		{Title: "Second title", XMLURL: "https://b.example.com/feed", HTMLURL: "https://b.example.com"},
+		{Title: "Third", XMLURL: "https://c.example.com/feed"},
+	}, doc.Feeds())
+
+	_, err = Parse(strings.NewReader("not xml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package presets
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/api/http/pb"
+	"github.com/egor3f/rssalchemy/internal/specs"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+//go:embed builtin
+var builtinFS embed.FS
+
+var nameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// ParamPrefix allows to render preset by name: /render/preset:<name>
+const ParamPrefix = "preset:"
+
+var ErrNotFound = errors.New("preset not found")
+
+// Preset is specs shared under a name. Spec has the same encoding as render url param,
+// optionally prefixed with rssalchemy: like presets copied from wizard.
+type Preset struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description" json:"description"`
+	Tags        []string `yaml:"tags" json:"tags"`
+	Spec        string   `yaml:"spec" json:"spec"`
+}
+
+// Registry holds presets by name. It's read-only after loading, so safe for concurrent use.
+type Registry struct {
+	presets map[string]Preset
+}
+
+// New loads builtin presets and presets from dir (if not empty).
+// Presets from dir replace builtin ones with the same name.
+func New(dir string) (*Registry, error) {
+	r := &Registry{presets: make(map[string]Preset)}
+	builtin, err := fs.Sub(builtinFS, "builtin")
+	if err != nil {
+		return nil, fmt.Errorf("builtin presets: %w", err)
+	}
+	if err := r.Load(builtin); err != nil {
+		return nil, fmt.Errorf("builtin presets: %w", err)
+	}
+	if dir != "" {
+		if err := r.Load(os.DirFS(dir)); err != nil {
+			return nil, fmt.Errorf("presets from %s: %w", dir, err)
+		}
+	}
+	return r, nil
+}
+
+// Load reads *.yaml, *.yml and *.json files from root of fsys, one preset per file
+func (r *Registry) Load(fsys fs.FS) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return fmt.Errorf("read dir: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !slices.Contains([]string{".yaml", ".yml", ".json"}, path.Ext(entry.Name())) {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return fmt.Errorf("read %s: %w", entry.Name(), err)
+		}
+		var preset Preset
+		// json is subset of yaml
+		if err := yaml.Unmarshal(data, &preset); err != nil {
+			return fmt.Errorf("parse %s: %w", entry.Name(), err)
+		}
+		if err := preset.validate(); err != nil {
+			return fmt.Errorf("invalid preset %s: %w", entry.Name(), err)
+		}
+		r.presets[preset.Name] = preset
+	}
+	return nil
+}
+
+func (p Preset) validate() error {
+	if !nameRegex.MatchString(p.Name) {
+		return fmt.Errorf("name must contain only lowercase letters, digits and dashes: %q", p.Name)
+	}
+	decoded, err := p.Specs()
+	if err != nil {
+		return err
+	}
+	// compiles every selector, so broken preset fails at startup rather than on render
+	return specs.Validate(decoded)
+}
+
+// Specs decodes spec of preset
+func (p Preset) Specs() (*pb.Specs, error) {
+	return specs.Decode(p.Spec)
+}
+
+// DecodeParam decodes and validates render url param, which is either encoded specs or preset:<name>
+func (r *Registry) DecodeParam(param string) (*pb.Specs, error) {
+	var decoded *pb.Specs
+	var err error
+	if name, isPreset := strings.CutPrefix(param, ParamPrefix); isPreset {
+		preset, ok := r.Get(name)
+		if !ok {
+			return nil, ErrNotFound
+		}
+		decoded, err = preset.Specs()
+	} else {
+		decoded, err = specs.Decode(param)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := specs.Validate(decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// Get returns preset by name
+func (r *Registry) Get(name string) (Preset, bool) {
+	preset, ok := r.presets[name]
+	return preset, ok
+}
+
+// Search returns presets sorted by name. Query matches name or description (case-insensitive),
+// tag must match exactly. Empty query and tag match everything.
+func (r *Registry) Search(query string, tag string) []Preset {
+	query = strings.ToLower(query)
+	result := make([]Preset, 0, len(r.presets))
+	for _, preset := range r.presets {
+		if tag != "" && !slices.Contains(preset.Tags, tag) {
+			continue
+		}
+		if query != "" &&
+			!strings.Contains(strings.ToLower(preset.Name), query) &&
+			!strings.Contains(strings.ToLower(preset.Description), query) {
+			continue
+		}
+		result = append(result, preset)
+	}
+	slices.SortFunc(result, func(a, b Preset) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result
+}
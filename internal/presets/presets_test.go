@@ -0,0 +1,100 @@
+package presets
+
+import (
+	"github.com/egor3f/rssalchemy/internal/specs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestBuiltin(t *testing.T) {
+	r, err := New("")
+	require.NoError(t, err)
+	all := r.Search("", "")
+	require.NotEmpty(t, all)
+	for _, preset := range all {
+		specs, err := preset.Specs()
+		require.NoError(t, err, preset.Name)
+		assert.NotEmpty(t, specs.Url, preset.Name)
+	}
+}
+
+func TestDirOverridesBuiltin(t *testing.T) {
+	builtin, err := New("")
+	require.NoError(t, err)
+	original, ok := builtin.Get("reddit-selfhosted")
+	require.True(t, ok)
+
+	dir := t.TempDir()
+	content := `{"name": "reddit-selfhosted", "description": "custom", "spec": "` + original.Spec + `"}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "reddit.json"), []byte(content), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644))
+
+	r, err := New(dir)
+	require.NoError(t, err)
+	preset, ok := r.Get("reddit-selfhosted")
+	require.True(t, ok)
+	assert.Equal(t, "custom", preset.Description)
+	assert.Len(t, r.Search("", ""), len(builtin.Search("", "")))
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"bad name", "name: Bad Name\nspec: rssalchemy:1:ZcuxCsIwEIBhpOAguBQXC4I4CmkUtzyCU3FyPZMjDfRyR+9QfHtHEcf/g3+1G81Eg/cgFV/aRyZvGMfKE+d3u07l2Q+QcZiZuGvHy7ecFZvwsPm3PRy3P6NLqHEuYoVrWAaNLHhfPLoEBk5YDZNLYOisEKoBybUplG/N+UQf"},
+		{"bad spec", "name: broken\nspec: rssalchemy:1:garbage"},
+		{"unsupported selector", "name: scope\nspec: rssalchemy:1:ZcuxCsIwEIBhpOAguBQXC4I4CmkUtzyCU3FyPZMjDfRyR+9QfHtHEcf/g3+1G81Eg/cgFV/aRyZvGMfKE+d3u07l2Q+QcZiZuGvHy7ecFZvwsPm3PRy3P6NLqHEuYoVrWAaNLHhfPLoEBk5YDZNLYOisEKoBybUplG/N+UQf"},
+		{"not yaml", "name: [broken"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Registry{presets: make(map[string]Preset)}
+			err := r.Load(fstest.MapFS{"preset.yaml": {Data: []byte(tt.content)}})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDecodeParam(t *testing.T) {
+	r, err := New("")
+	require.NoError(t, err)
+	preset, ok := r.Get("reddit-selfhosted")
+	require.True(t, ok)
+	want, err := preset.Specs()
+	require.NoError(t, err)
+	encoded := strings.TrimPrefix(preset.Spec, specs.PresetPrefix)
+
+	tests := []struct {
+		name      string
+		param     string
+		wantErr   bool
+		wantErrIs error
+	}{
+		{name: "preset name", param: ParamPrefix + preset.Name},
+		{name: "encoded specs", param: encoded},
+		{name: "preset string", param: specs.PresetPrefix + encoded},
+		{name: "unknown preset", param: ParamPrefix + "unknown", wantErr: true, wantErrIs: ErrNotFound},
+		{name: "garbage", param: "1:garbage", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := r.DecodeParam(tt.param)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, want.Url, decoded.Url)
+			assert.Equal(t, want.SelectorPost, decoded.SelectorPost)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidSignature = fmt.Errorf("invalid signature")
+	ErrUnknownKey       = fmt.Errorf("unknown signing key")
+
+	keyIDRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+const minSecretLength = 16
+
+type key struct {
+	id     string
+	secret []byte
+}
+
+// Signer makes and verifies signatures of url params in format <key id>.<base64url hmac-sha256>.
+// The first key signs, all keys verify, so keys are rotated by prepending new one and removing old one
+// when urls signed by it are not needed anymore.
+type Signer struct {
+	keys []key
+}
+
+// New creates signer from entries in format id:secret
+func New(entries []string) (*Signer, error) {
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no signing keys")
+	}
+	s := &Signer{}
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		id, secret, found := strings.Cut(entry, ":")
+		if !found || !keyIDRegex.MatchString(id) {
+			return nil, fmt.Errorf("invalid signing key entry, expected id:secret")
+		}
+		if len(secret) < minSecretLength {
+			return nil, fmt.Errorf("signing key %s: secret must be at least %d chars", id, minSecretLength)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate signing key id: %s", id)
+		}
+		seen[id] = true
+		s.keys = append(s.keys, key{id: id, secret: []byte(secret)})
+	}
+	return s, nil
+}
+
+func (s *Signer) Sign(value string) string {
+	k := s.keys[0]
+	return k.id + "." + base64.RawURLEncoding.EncodeToString(mac(k.secret, value))
+}
+
+func (s *Signer) Verify(value string, signature string) error {
+	id, encoded, found := strings.Cut(signature, ".")
+	if !found {
+		return ErrInvalidSignature
+	}
+	sum, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	for _, k := range s.keys {
+		if k.id != id {
+			continue
+		}
+		if !hmac.Equal(sum, mac(k.secret, value)) {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+	return ErrUnknownKey
+}
+
+func mac(secret []byte, value string) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(value))
+	return h.Sum(nil)
+}
@@ -0,0 +1,67 @@
+package signing
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		wantErr bool
+	}{
+		{"single key", []string{"k1:0123456789abcdef"}, false},
+		{"rotation", []string{"k2:0123456789abcdef", "k1:fedcba9876543210"}, false},
+		{"no keys", nil, true},
+		{"no id", []string{"0123456789abcdef"}, true},
+		{"invalid id", []string{"k.1:0123456789abcdef"}, true},
+		{"short secret", []string{"k1:short"}, true},
+		{"duplicate id", []string{"k1:0123456789abcdef", "k1:fedcba9876543210"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.entries)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	oldSigner, err := New([]string{"k1:fedcba9876543210"})
+	require.NoError(t, err)
+	signer, err := New([]string{"k2:0123456789abcdef", "k1:fedcba9876543210"})
+	require.NoError(t, err)
+	otherSigner, err := New([]string{"k2:aaaaaaaaaaaaaaaa"})
+	require.NoError(t, err)
+
+	value := "1:eJzLKCkpKLbS1y8vL9fLz"
+	sig := signer.Sign(value)
+	assert.Regexp(t, `^k2\.[A-Za-z0-9_-]+$`, sig)
+
+	tests := []struct {
+		name    string
+		value   string
+		sig     string
+		wantErr error
+	}{
+		{"valid", value, sig, nil},
+		{"signed by rotated key", value, oldSigner.Sign(value), nil},
+		{"tampered value", value + "A", sig, ErrInvalidSignature},
+		{"other secret", value, otherSigner.Sign(value), ErrInvalidSignature},
+		{"unknown key", value, "k3" + sig[2:], ErrUnknownKey},
+		{"no key id", value, sig[3:], ErrInvalidSignature},
+		{"invalid base64", value, "k2.***", ErrInvalidSignature},
+		{"empty", value, "", ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, signer.Verify(tt.value, tt.sig), tt.wantErr)
+		})
+	}
+}
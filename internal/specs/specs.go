@@ -0,0 +1,150 @@
+package specs
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/api/http/pb"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PresetPrefix marks specs shared as preset, it's the same encoding as in render url
+const PresetPrefix = "rssalchemy:"
+
+// Version is version of encoding produced by Encode, same as wizard produces
+const Version = 1
+
+// RenderPath precedes encoded specs in render url
+const RenderPath = "/api/v1/render/"
+
+// FeedPath precedes short link id in feed url
+const FeedPath = "/api/v1/feed/"
+
+var encoding = base64.StdEncoding.WithPadding(base64.NoPadding)
+
+// Decode decodes specs from render url param or preset in format [rssalchemy:][version:]base64(flate(data)),
+// where data is json for version 0 and protobuf for version 1. Specs are not validated.
+func Decode(encoded string) (*pb.Specs, error) {
+	version, encoded, err := splitVersion(strings.TrimPrefix(encoded, PresetPrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	// padding is not produced by wizard, but tolerated like in wizard
+	decoded, err := encoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode specs: %w", err)
+	}
+	rc := flate.NewReader(bytes.NewReader(decoded))
+	decoded, err = io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unzip specs: %w", err)
+	}
+
+	specs := &pb.Specs{}
+	switch version {
+	case 0:
+		if err := json.Unmarshal(decoded, specs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal json specs: %w", err)
+		}
+	case 1:
+		if err := proto.Unmarshal(decoded, specs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal proto specs: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown version: %d", version)
+	}
+	return specs, nil
+}
+
+// Encode encodes specs for render url like wizard does (encodeSpecsPart in frontend urlmaker):
+// version:base64(flate(protobuf)). Specs are not validated.
+func Encode(specs *pb.Specs) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(specs)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal specs: %w", err)
+	}
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", fmt.Errorf("failed to zip specs: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", fmt.Errorf("failed to zip specs: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("failed to zip specs: %w", err)
+	}
+	return fmt.Sprintf("%d:%s", Version, encoding.EncodeToString(buf.Bytes())), nil
+}
+
+// EncodePreset encodes specs as preset string, which can be pasted into wizard
+func EncodePreset(specs *pb.Specs) (string, error) {
+	encoded, err := Encode(specs)
+	if err != nil {
+		return "", err
+	}
+	return PresetPrefix + encoded, nil
+}
+
+// Upgrade re-encodes specs (or preset) of any version with current Version, preset prefix is kept
+func Upgrade(encoded string) (string, error) {
+	decoded, err := Decode(encoded)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(encoded, PresetPrefix) {
+		return EncodePreset(decoded)
+	}
+	return Encode(decoded)
+}
+
+// EncodedVersion returns encoding version of specs or preset without decoding them
+func EncodedVersion(encoded string) (int, error) {
+	version, _, err := splitVersion(strings.TrimPrefix(encoded, PresetPrefix))
+	return version, err
+}
+
+// RenderParam returns encoded specs part of render url
+func RenderParam(renderURL string) (string, error) {
+	u, err := url.Parse(renderURL)
+	if err != nil {
+		return "", fmt.Errorf("parse url: %w", err)
+	}
+	_, escaped, found := strings.Cut(u.EscapedPath(), RenderPath)
+	if !found || escaped == "" {
+		return "", fmt.Errorf("not a render url: %s", renderURL)
+	}
+	param, err := url.PathUnescape(escaped)
+	if err != nil {
+		return "", fmt.Errorf("unescape specs: %w", err)
+	}
+	return param, nil
+}
+
+// DecodeURL decodes specs of render url
+func DecodeURL(renderURL string) (*pb.Specs, error) {
+	param, err := RenderParam(renderURL)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(param)
+}
+
+func splitVersion(encoded string) (int, string, error) {
+	paramSplit := strings.Split(encoded, ":")
+	if len(paramSplit) != 2 {
+		return 0, encoded, nil
+	}
+	version, err := strconv.Atoi(paramSplit[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid version: %s", paramSplit[0])
+	}
+	return version, paramSplit[1], nil
+}
@@ -0,0 +1,118 @@
+package specs
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/json"
+	"testing"
+
+	"github.com/egor3f/rssalchemy/internal/api/http/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func testSpecs() *pb.Specs {
+	return &pb.Specs{
+		Url:                  "https://example.com/news",
+		SelectorPost:         "article",
+		SelectorTitle:        "h2",
+		SelectorLink:         "a",
+		SelectorCreated:      "time",
+		CreatedExtractFrom:   pb.ExtractFrom_Attribute,
+		CreatedAttributeName: "datetime",
+	}
+}
+
+// encodeV0 encodes specs like old wizard did: json instead of protobuf and no version
+func encodeV0(t *testing.T, s *pb.Specs) string {
+	t.Helper()
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return encoding.EncodeToString(buf.Bytes())
+}
+
+func TestEncodeDecode(t *testing.T) {
+	encoded, err := Encode(testSpecs())
+	require.NoError(t, err)
+	assert.Regexp(t, `^1:[A-Za-z0-9+/]+$`, encoded)
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"encoded", encoded},
+		{"preset", PresetPrefix + encoded},
+		{"padded", encoded + "=="},
+		{"v0", encodeV0(t, testSpecs())},
+		{"v0 with version", "0:" + encodeV0(t, testSpecs())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := Decode(tt.encoded)
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(testSpecs(), decoded), "decoded: %v", decoded)
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, encoded := range []string{"x:AAAA", "2:" + encodeV0(t, testSpecs()), "1:!!!", "1:AAAA"} {
+		_, err := Decode(encoded)
+		assert.Error(t, err, encoded)
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	v0 := encodeV0(t, testSpecs())
+	version, err := EncodedVersion(PresetPrefix + v0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, version)
+
+	upgraded, err := Upgrade(PresetPrefix + v0)
+	require.NoError(t, err)
+	want, err := EncodePreset(testSpecs())
+	require.NoError(t, err)
+	assert.Equal(t, want, upgraded)
+
+	upgraded, err = Upgrade(v0)
+	require.NoError(t, err)
+	version, err = EncodedVersion(upgraded)
+	require.NoError(t, err)
+	assert.Equal(t, Version, version)
+}
+
+func TestRenderParam(t *testing.T) {
+	encoded, err := Encode(testSpecs())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{"plain", "https://rss.example.com/api/v1/render/" + encoded, encoded, false},
+		{"signed", "https://rss.example.com/api/v1/render/" + encoded + "?sig=abc", encoded, false},
+		{"escaped", "https://rss.example.com/api/v1/render/1%3Aab%2Fc%2Bd", "1:ab/c+d", false},
+		{"not render", "https://rss.example.com/api/v1/feed/abc", "", true},
+		{"empty", "https://rss.example.com/api/v1/render/", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			param, err := RenderParam(tt.url)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, param)
+		})
+	}
+}
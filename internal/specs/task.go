@@ -0,0 +1,94 @@
+package specs
+
+import (
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/api/http/pb"
+	"github.com/egor3f/rssalchemy/internal/models"
+)
+
+// ToTask converts specs to extract task. Headers are not set, they are taken from request.
+func ToTask(specs *pb.Specs) (models.Task, error) {
+	extractFromValues := map[pb.ExtractFrom]models.ExtractFrom{
+		pb.ExtractFrom_InnerText: models.ExtractFrom_InnerText,
+		pb.ExtractFrom_Attribute: models.ExtractFrom_Attribute,
+	}
+	var extractFromErr error
+	extractFrom := func(v pb.ExtractFrom) models.ExtractFrom {
+		ef, ok := extractFromValues[v]
+		if !ok {
+			extractFromErr = fmt.Errorf("invalid extract from")
+		}
+		return ef
+	}
+
+	taskType, ok := map[pb.SourceType]models.TaskType{
+		pb.SourceType_Html: models.TaskTypeExtract,
+		pb.SourceType_Json: models.TaskTypeExtractJSON,
+	}[specs.SourceType]
+	if !ok {
+		return models.Task{}, fmt.Errorf("invalid source type")
+	}
+
+	selectorTypes := map[pb.SelectorType]models.SelectorType{
+		pb.SelectorType_Css:   models.SelectorType_CSS,
+		pb.SelectorType_XPath: models.SelectorType_XPath,
+	}
+	var selectorTypeErr error
+	selectorType := func(t pb.SelectorType) models.SelectorType {
+		st, ok := selectorTypes[t]
+		if !ok {
+			selectorTypeErr = fmt.Errorf("invalid selector type")
+		}
+		return st
+	}
+
+	task := models.Task{
+		TaskType:                 taskType,
+		URL:                      specs.Url,
+		SelectorPost:             specs.SelectorPost,
+		SelectorPostType:         selectorType(specs.SelectorPostType),
+		SelectorTitle:            specs.SelectorTitle,
+		SelectorTitleType:        selectorType(specs.SelectorTitleType),
+		TitleExtractFrom:         extractFrom(specs.TitleExtractFrom),
+		TitleAttributeName:       specs.TitleAttributeName,
+		SelectorLink:             specs.SelectorLink,
+		SelectorLinkType:         selectorType(specs.SelectorLinkType),
+		LinkAttributeName:        specs.LinkAttributeName,
+		SelectorDescription:      specs.SelectorDescription,
+		SelectorDescriptionType:  selectorType(specs.SelectorDescriptionType),
+		DescriptionExtractFrom:   extractFrom(specs.DescriptionExtractFrom),
+		DescriptionAttributeName: specs.DescriptionAttributeName,
+		SelectorAuthor:           specs.SelectorAuthor,
+		SelectorAuthorType:       selectorType(specs.SelectorAuthorType),
+		AuthorExtractFrom:        extractFrom(specs.AuthorExtractFrom),
+		AuthorAttributeName:      specs.AuthorAttributeName,
+		AuthorLinkAttributeName:  specs.AuthorLinkAttributeName,
+		SelectorCreated:          specs.SelectorCreated,
+		SelectorCreatedType:      selectorType(specs.SelectorCreatedType),
+		CreatedExtractFrom:       extractFrom(specs.CreatedExtractFrom),
+		CreatedAttributeName:     specs.CreatedAttributeName,
+		DateFormats:              specs.DateFormats,
+		DateLanguages:            specs.DateLanguages,
+		DateTimezone:             specs.DateTimezone,
+		DateDayFirst:             specs.DateDayFirst,
+		SelectorContent:          specs.SelectorContent,
+		SelectorContentType:      selectorType(specs.SelectorContentType),
+		SelectorEnclosure:        specs.SelectorEnclosure,
+		SelectorEnclosureType:    selectorType(specs.SelectorEnclosureType),
+		EnclosureAttributeName:   specs.EnclosureAttributeName,
+		TransformTitle:           specs.TransformTitle,
+		TransformLink:            specs.TransformLink,
+		TransformDescription:     specs.TransformDescription,
+		TransformAuthor:          specs.TransformAuthor,
+		TransformCreated:         specs.TransformCreated,
+		TransformContent:         specs.TransformContent,
+		TransformEnclosure:       specs.TransformEnclosure,
+	}
+	if extractFromErr != nil {
+		return models.Task{}, extractFromErr
+	}
+	if selectorTypeErr != nil {
+		return models.Task{}, selectorTypeErr
+	}
+	return task, nil
+}
@@ -0,0 +1,34 @@
+package specs
+
+import (
+	"fmt"
+	"github.com/egor3f/rssalchemy/internal/api/http/pb"
+	"github.com/egor3f/rssalchemy/internal/validators"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/gommon/log"
+)
+
+var validate = func() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	if err := v.RegisterValidation("selector", validators.ValidateSelector); err != nil {
+		log.Panicf("register validation: %v", err)
+	}
+	if err := v.RegisterValidation("transform", validators.ValidateTransform); err != nil {
+		log.Panicf("register validation: %v", err)
+	}
+	if err := v.RegisterValidation("date_formats", validators.ValidateDateFormats); err != nil {
+		log.Panicf("register validation: %v", err)
+	}
+	if err := v.RegisterValidation("date_languages", validators.ValidateDateLanguages); err != nil {
+		log.Panicf("register validation: %v", err)
+	}
+	return v
+}()
+
+// Validate checks specs the same way as render endpoint does
+func Validate(specs *pb.Specs) error {
+	if err := validate.Struct(specs); err != nil {
+		return fmt.Errorf("specs are invalid: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package specs
+
+import (
+	"testing"
+
+	"github.com/egor3f/rssalchemy/internal/api/http/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDateLanguages(t *testing.T) {
+	for _, tt := range []struct {
+		languages string
+		valid     bool
+	}{
+		{"", true},
+		{"en", true},
+		{"en, ru", true},
+		{"xx", false},
+		{"en, english", false},
+	} {
+		t.Run(tt.languages, func(t *testing.T) {
+			s := testSpecs()
+			s.SelectorEnclosure = "img"
+			s.DateLanguages = tt.languages
+			err := Validate(s)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, "DateLanguages")
+			}
+		})
+	}
+}
+
+func TestValidateSelectors(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *pb.Specs)
+		wantErr string
+	}{
+		{name: "ok", modify: func(s *pb.Specs) {}},
+		{
+			name:    "css syntax error",
+			modify:  func(s *pb.Specs) { s.SelectorTitle = "h3[" },
+			wantErr: "SelectorTitle",
+		},
+		{
+			name:    "css parsed but not matchable",
+			modify:  func(s *pb.Specs) { s.SelectorCreated = ":scope" },
+			wantErr: "SelectorCreated",
+		},
+		{
+			name:    "css has",
+			modify:  func(s *pb.Specs) { s.SelectorAuthor = "a:has(> span.author)" },
+			wantErr: "SelectorAuthor",
+		},
+		{
+			name: "xpath",
+			modify: func(s *pb.Specs) {
+				s.SelectorLink, s.SelectorLinkType = "//h3/a", pb.SelectorType_XPath
+			},
+		},
+		{
+			name: "xpath syntax error",
+			modify: func(s *pb.Specs) {
+				s.SelectorLink, s.SelectorLinkType = "//h3/a[", pb.SelectorType_XPath
+			},
+			wantErr: "SelectorLink",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testSpecs()
+			s.SelectorEnclosure = "img"
+			tt.modify(s)
+			err := Validate(s)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAttributeNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *pb.Specs)
+		wantErr string
+	}{
+		{name: "ok", modify: func(s *pb.Specs) {}},
+		{
+			name:    "created attribute without name",
+			modify:  func(s *pb.Specs) { s.CreatedAttributeName = "" },
+			wantErr: "CreatedAttributeName",
+		},
+		{
+			name:    "title attribute without name",
+			modify:  func(s *pb.Specs) { s.TitleExtractFrom = pb.ExtractFrom_Attribute },
+			wantErr: "TitleAttributeName",
+		},
+		{
+			name: "description attribute without name",
+			modify: func(s *pb.Specs) {
+				s.SelectorDescription = "p"
+				s.DescriptionExtractFrom = pb.ExtractFrom_Attribute
+			},
+			wantErr: "DescriptionAttributeName",
+		},
+		{
+			name: "author attribute without name",
+			modify: func(s *pb.Specs) {
+				s.SelectorAuthor = ".author"
+				s.AuthorExtractFrom = pb.ExtractFrom_Attribute
+			},
+			wantErr: "AuthorAttributeName",
+		},
+		{
+			name: "attributes with names",
+			modify: func(s *pb.Specs) {
+				s.TitleExtractFrom, s.TitleAttributeName = pb.ExtractFrom_Attribute, "title"
+				s.SelectorAuthor = ".author"
+				s.AuthorExtractFrom, s.AuthorAttributeName = pb.ExtractFrom_Attribute, "data-name"
+			},
+		},
+		{
+			name:   "inner text without name",
+			modify: func(s *pb.Specs) { s.CreatedExtractFrom, s.CreatedAttributeName = pb.ExtractFrom_InnerText, "" },
+		},
+		{
+			name:   "link, author link and enclosure names are optional",
+			modify: func(s *pb.Specs) { s.SelectorAuthor = ".author" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testSpecs()
+			s.SelectorEnclosure = "img"
+			tt.modify(s)
+			err := Validate(s)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"context"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func TestNatsPropagation(t *testing.T) {
+	_, err := Setup(context.Background(), Config{})
+	require.NoError(t, err)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	ctx, producer := tracer.Start(context.Background(), "producer")
+	header := nats.Header{}
+	InjectNats(ctx, header)
+	producer.End()
+	assert.NotEmpty(t, header.Get("Traceparent"))
+
+	_, consumer := tracer.Start(ExtractNats(context.Background(), header), "consumer")
+	consumer.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, spans[0].SpanContext().TraceID(), spans[1].SpanContext().TraceID())
+	assert.Equal(t, spans[0].SpanContext().SpanID(), spans[1].Parent().SpanID())
+	assert.True(t, spans[1].Parent().IsRemote())
+}
+
+func TestExtractNatsWithoutHeaders(t *testing.T) {
+	ctx := ExtractNats(context.Background(), nil)
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
+
+func TestSetup(t *testing.T) {
+	for _, endpoint := range []string{"", "http://localhost:4318"} {
+		shutdown, err := Setup(context.Background(), Config{Endpoint: endpoint, ServiceName: "test", SampleRatio: 1})
+		require.NoError(t, err, endpoint)
+		assert.NoError(t, shutdown(context.Background()), endpoint)
+	}
+}
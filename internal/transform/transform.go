@@ -0,0 +1,187 @@
+// Package transform implements post-processing of extracted field values.
+//
+// Transform is a list of steps, one per line, applied in order:
+//
+//	trim [chars]               - trim whitespace (or given chars) on both sides
+//	extract /regex/ [group]    - replace value with regex match (first capture group by default)
+//	replace /regex/ replacement - replace all matches, $1 and ${name} refer to groups
+//	prefix text                - prepend text
+//	suffix text                - append text
+//	template text              - replace value with text, {field} is substituted with
+//	                             extracted field value, {value} with current value
+//
+// Empty lines and lines starting with # are ignored. Slash inside regex is escaped as \/.
+// Content is transformed as unsanitized html of the page, see ParseHTML.
+package transform
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	FieldTitle       = "title"
+	FieldLink        = "link"
+	FieldDescription = "description"
+	FieldAuthor      = "author"
+	FieldCreated     = "created"
+	FieldContent     = "content"
+	FieldEnclosure   = "enclosure"
+)
+
+// Fields lists transformable fields in order of application
+var Fields = []string{
+	FieldTitle, FieldLink, FieldDescription, FieldAuthor, FieldCreated, FieldContent, FieldEnclosure,
+}
+
+// currentValue is template placeholder for value of transformed field
+const currentValue = "value"
+
+var placeholderRe = regexp.MustCompile(`\{([a-z_]+)}`)
+
+type step func(value string, fields map[string]string) string
+
+type Pipeline struct {
+	steps []step
+}
+
+// Parse compiles transform. Empty string gives pipeline which returns value as is.
+func Parse(s string) (*Pipeline, error) {
+	return parse(s, false)
+}
+
+// ParseHTML compiles transform of field holding html, like content. Text fields substituted
+// by template are html-escaped; {value} and {content} are html already and substituted as is.
+func ParseHTML(s string) (*Pipeline, error) {
+	return parse(s, true)
+}
+
+func parse(s string, escapeText bool) (*Pipeline, error) {
+	p := &Pipeline{}
+	for i, line := range strings.Split(s, "\n") {
+		line = strings.TrimLeft(strings.TrimRight(line, "\r"), " \t")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		st, err := parseStep(line, escapeText)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		p.steps = append(p.steps, st)
+	}
+	return p, nil
+}
+
+// Apply runs all steps on value. Fields are used to fill templates.
+func (p *Pipeline) Apply(value string, fields map[string]string) string {
+	if p == nil {
+		return value
+	}
+	for _, st := range p.steps {
+		value = st(value, fields)
+	}
+	return value
+}
+
+func parseStep(line string, escapeText bool) (step, error) {
+	name, arg, _ := strings.Cut(line, " ")
+	switch name {
+	case "trim":
+		if arg == "" {
+			return func(v string, _ map[string]string) string { return strings.TrimSpace(v) }, nil
+		}
+		return func(v string, _ map[string]string) string { return strings.Trim(v, arg) }, nil
+	case "extract":
+		return parseExtract(arg)
+	case "replace":
+		re, repl, err := parseRegex(arg)
+		if err != nil {
+			return nil, err
+		}
+		return func(v string, _ map[string]string) string { return re.ReplaceAllString(v, repl) }, nil
+	case "prefix":
+		return func(v string, _ map[string]string) string { return arg + v }, nil
+	case "suffix":
+		return func(v string, _ map[string]string) string { return v + arg }, nil
+	case "template":
+		return parseTemplate(arg, escapeText)
+	default:
+		return nil, fmt.Errorf("unknown step %q", name)
+	}
+}
+
+func parseExtract(arg string) (step, error) {
+	re, groupStr, err := parseRegex(arg)
+	if err != nil {
+		return nil, err
+	}
+	group := 0
+	if re.NumSubexp() > 0 {
+		group = 1
+	}
+	groupStr = strings.TrimSpace(groupStr)
+	if groupStr != "" {
+		if group, err = strconv.Atoi(groupStr); err != nil {
+			group = re.SubexpIndex(groupStr)
+		}
+		if group < 0 || group > re.NumSubexp() {
+			return nil, fmt.Errorf("regex has no group %s", groupStr)
+		}
+	}
+	return func(v string, _ map[string]string) string {
+		match := re.FindStringSubmatch(v)
+		if match == nil {
+			return ""
+		}
+		return match[group]
+	}, nil
+}
+
+// parseRegex parses /regex/ at the beginning of arg and returns the rest after it
+func parseRegex(arg string) (*regexp.Regexp, string, error) {
+	arg = strings.TrimLeft(arg, " \t")
+	if !strings.HasPrefix(arg, "/") {
+		return nil, "", fmt.Errorf("regex must be enclosed in slashes")
+	}
+	var b strings.Builder
+	for i := 1; i < len(arg); i++ {
+		switch {
+		case arg[i] == '\\' && i+1 < len(arg) && arg[i+1] == '/':
+			b.WriteByte('/')
+			i++
+		case arg[i] == '/':
+			re, err := regexp.Compile(b.String())
+			if err != nil {
+				return nil, "", fmt.Errorf("regex: %w", err)
+			}
+			return re, strings.TrimPrefix(arg[i+1:], " "), nil
+		default:
+			b.WriteByte(arg[i])
+		}
+	}
+	return nil, "", fmt.Errorf("regex is not terminated")
+}
+
+func parseTemplate(tmpl string, escapeText bool) (step, error) {
+	for _, m := range placeholderRe.FindAllStringSubmatch(tmpl, -1) {
+		if m[1] != currentValue && !slices.Contains(Fields, m[1]) {
+			return nil, fmt.Errorf("unknown field {%s} in template", m[1])
+		}
+	}
+	return func(v string, fields map[string]string) string {
+		return placeholderRe.ReplaceAllStringFunc(tmpl, func(ph string) string {
+			name := ph[1 : len(ph)-1]
+			if name == currentValue {
+				return v
+			}
+			if escapeText && name != FieldContent {
+				return html.EscapeString(fields[name])
+			}
+			return fields[name]
+		})
+	}, nil
+}
@@ -0,0 +1,170 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	fields := map[string]string{
+		FieldTitle:  "NEW! Some title",
+		FieldAuthor: "John",
+	}
+	tests := []struct {
+		name      string
+		transform string
+		input     string
+		expected  string
+	}{
+		{
+			name:      "empty",
+			transform: "",
+			input:     " as is ",
+			expected:  " as is ",
+		},
+		{
+			name:      "trim",
+			transform: "trim",
+			input:     "  value \n",
+			expected:  "value",
+		},
+		{
+			name:      "trim chars",
+			transform: "trim *-",
+			input:     "**-value-*",
+			expected:  "value",
+		},
+		{
+			name:      "replace",
+			transform: "replace /^NEW! /",
+			input:     "NEW! Some title",
+			expected:  "Some title",
+		},
+		{
+			name:      "replace with group",
+			transform: `replace /(\d+) views/ $1 просмотров`,
+			input:     "100 views",
+			expected:  "100 просмотров",
+		},
+		{
+			name:      "extract first group",
+			transform: `extract /published (\d{4}-\d{2}-\d{2})/`,
+			input:     "by John, published 2024-01-02, 100 views",
+			expected:  "2024-01-02",
+		},
+		{
+			name:      "extract whole match",
+			transform: `extract /\d+/`,
+			input:     "100 views",
+			expected:  "100",
+		},
+		{
+			name:      "extract named group",
+			transform: `extract /(?P<day>\d+)\/(?P<month>\d+)/ month`,
+			input:     "date: 12/05",
+			expected:  "05",
+		},
+		{
+			name:      "extract no match",
+			transform: `extract /\d+/`,
+			input:     "no digits",
+			expected:  "",
+		},
+		{
+			name:      "prefix and suffix",
+			transform: "prefix [\nsuffix ] ",
+			input:     "value",
+			expected:  "[value] ",
+		},
+		{
+			name:      "template",
+			transform: "replace /^NEW! /\ntemplate {author}: {value}",
+			input:     "NEW! Some title",
+			expected:  "John: Some title",
+		},
+		{
+			name:      "template uses raw fields",
+			transform: "template {title} ({description})",
+			input:     "ignored",
+			expected:  "NEW! Some title ()",
+		},
+		{
+			name:      "comments and empty lines",
+			transform: "# cleanup\n\n  trim\n",
+			input:     " value ",
+			expected:  "value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Parse(tt.transform)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, p.Apply(tt.input, fields))
+		})
+	}
+}
+
+func TestApplyHTML(t *testing.T) {
+	fields := map[string]string{
+		FieldTitle:   `<script>alert("x")</script> & co`,
+		FieldContent: "<p>text</p>",
+	}
+	tests := []struct {
+		name      string
+		transform string
+		input     string
+		expected  string
+	}{
+		{
+			name:      "text field is escaped",
+			transform: "template <h1>{title}</h1>{value}",
+			input:     "<p>text</p>",
+			expected:  "<h1>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; &amp; co</h1><p>text</p>",
+		},
+		{
+			name:      "content is not escaped",
+			transform: "template {content}<hr>",
+			input:     "",
+			expected:  "<p>text</p><hr>",
+		},
+		{
+			name:      "other steps are not changed",
+			transform: "prefix <b>\nsuffix </b>",
+			input:     "a & b",
+			expected:  "<b>a & b</b>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParseHTML(tt.transform)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, p.Apply(tt.input, fields))
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform string
+	}{
+		{"unknown step", "uppercase"},
+		{"regex without slashes", "replace abc def"},
+		{"unterminated regex", "extract /abc"},
+		{"invalid regex", "extract /(abc/"},
+		{"missing group", "extract /(a)(b)/ 3"},
+		{"missing named group", "extract /(?P<x>a)/ y"},
+		{"unknown template field", "template {foo}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.transform)
+			assert.Error(t, err)
+		})
+	}
+}
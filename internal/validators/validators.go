@@ -1,19 +1,105 @@
 package validators
 
 import (
+	"fmt"
+	"github.com/antchfx/xpath"
+	"github.com/egor3f/rssalchemy/internal/dateparser"
+	"github.com/egor3f/rssalchemy/internal/transform"
 	"github.com/ericchiang/css"
 	"github.com/go-playground/validator/v10"
+	"github.com/jmespath/go-jmespath"
 	"github.com/labstack/gommon/log"
 	"reflect"
+	"regexp"
+	"strings"
 )
 
+type SelectorSyntax int
+
+const (
+	SyntaxCSS SelectorSyntax = iota
+	SyntaxXPath
+	SyntaxJmesPath
+)
+
+// SelectorSyntaxer is implemented by structs whose selector fields are not always CSS selectors
+type SelectorSyntaxer interface {
+	SelectorSyntax(fieldName string) SelectorSyntax
+}
+
 func ValidateSelector(fl validator.FieldLevel) bool {
 	if fl.Field().Kind() != reflect.String {
 		return false
 	}
-	_, err := css.Parse(fl.Field().String())
+	var err error
+	switch fieldSyntax(fl) {
+	case SyntaxXPath:
+		_, err = xpath.Compile(fl.Field().String())
+	case SyntaxJmesPath:
+		_, err = jmespath.Compile(fl.Field().String())
+	default:
+		err = CompileCSS(fl.Field().String())
+	}
 	if err != nil {
 		log.Debugf("selector %s invalid: %v", fl.Field().String(), err)
 	}
 	return err == nil
 }
+
+// parseOnlyPseudoClasses are parsed by css fork for validation of browser selectors, but it panics when matching them
+var parseOnlyPseudoClasses = regexp.MustCompile(`(?i):(scope|has\()`)
+
+// CompileCSS checks that css selector can be matched by extractor, not only parsed
+func CompileCSS(selector string) error {
+	if _, err := css.Parse(selector); err != nil {
+		return err
+	}
+	if pseudo := parseOnlyPseudoClasses.FindString(selector); pseudo != "" {
+		return fmt.Errorf("pseudo-class %s is not supported", strings.TrimSuffix(pseudo, "("))
+	}
+	return nil
+}
+
+func fieldSyntax(fl validator.FieldLevel) SelectorSyntax {
+	parent := fl.Parent()
+	if !parent.CanAddr() {
+		return SyntaxCSS
+	}
+	if s, ok := parent.Addr().Interface().(SelectorSyntaxer); ok {
+		return s.SelectorSyntax(fl.StructFieldName())
+	}
+	return SyntaxCSS
+}
+
+func ValidateTransform(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	if _, err := transform.Parse(fl.Field().String()); err != nil {
+		log.Debugf("transform %s invalid: %v", fl.Field().String(), err)
+		return false
+	}
+	return true
+}
+
+func ValidateDateFormats(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	if _, err := dateparser.ParseOptions(fl.Field().String(), "", "", false); err != nil {
+		log.Debugf("date formats %s invalid: %v", fl.Field().String(), err)
+		return false
+	}
+	return true
+}
+
+func ValidateDateLanguages(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	if _, err := dateparser.ParseOptions("", fl.Field().String(), "", false); err != nil {
+		log.Debugf("date languages %s invalid: %v", fl.Field().String(), err)
+		return false
+	}
+	return true
+}